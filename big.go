@@ -0,0 +1,398 @@
+package cvt
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+)
+
+// BigInt convert an interface to a *big.Int type, with default value
+func BigInt(v interface{}, def ...*big.Int) *big.Int {
+	if v, err := BigIntE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return new(big.Int)
+}
+
+// BigIntE convert an interface to a *big.Int type
+// * string supports any base with prefix, eg: "0x1f", "0b101", "0o17"
+// * the decimal part of float or decimal string will be truncated
+// * NaN and ±Inf are handled by NonFinite, they are errors if kept
+func BigIntE(val interface{}) (*big.Int, error) {
+	v, e := convBigInt(val)
+	if e := catch("*big.Int", val, e); e != nil {
+		return nil, e
+	}
+
+	return v, nil
+}
+
+// BigFloat convert an interface to a *big.Float type, with default value
+func BigFloat(v interface{}, def ...*big.Float) *big.Float {
+	if v, err := BigFloatE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return new(big.Float)
+}
+
+// BigFloatE convert an interface to a *big.Float type
+// the precision of string is enough to keep all the digits
+// NaN and ±Inf are handled by NonFinite, NaN is an error if it's kept
+func BigFloatE(val interface{}) (*big.Float, error) {
+	v, e := convBigFloat(val)
+	if e == nil {
+		v, e = checkNonFiniteBig(v)
+	}
+	if e := catch("*big.Float", val, e); e != nil {
+		return nil, e
+	}
+
+	return v, nil
+}
+
+// BigRat convert an interface to a *big.Rat type, with default value
+func BigRat(v interface{}, def ...*big.Rat) *big.Rat {
+	if v, err := BigRatE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return new(big.Rat)
+}
+
+// BigRatE convert an interface to a *big.Rat type
+// * string supports fraction and decimal, eg: "3/4", "1.25", "1e-3"
+// * NaN and ±Inf are handled by NonFinite, they are errors if kept
+func BigRatE(val interface{}) (*big.Rat, error) {
+	v, e := convBigRat(val)
+	if e := catch("*big.Rat", val, e); e != nil {
+		return nil, e
+	}
+
+	return v, nil
+}
+
+// convert any value to *big.Int
+func convBigInt(val interface{}) (*big.Int, error) {
+	switch vv := val.(type) {
+	case *big.Int:
+		if vv != nil {
+			return new(big.Int).Set(vv), nil
+		}
+	case *big.Float:
+		if vv != nil {
+			return bigFloat2int(vv)
+		}
+	case *big.Rat:
+		if vv != nil {
+			return bigRat2int(vv), nil
+		}
+	case json.Number:
+		return str2bigInt(vv.String())
+	case time.Duration:
+		i, err := convInt64(vv)
+		return big.NewInt(i), err
+	}
+
+	v, rv := indirectScalar(val)
+	switch vv := v.(type) {
+	case nil:
+		return new(big.Int), nil
+	case big.Int:
+		return new(big.Int).Set(&vv), nil
+	case big.Float:
+		return bigFloat2int(&vv)
+	case big.Rat:
+		return bigRat2int(&vv), nil
+	case bool:
+		if vv {
+			return big.NewInt(1), nil
+		}
+		return new(big.Int), nil
+	case string:
+		return str2bigInt(vv)
+	case []byte:
+		return str2bigInt(string(vv))
+	case int, int8, int16, int32, int64:
+		return big.NewInt(rv.Int()), nil
+	case uint, uint8, uint16, uint32, uint64, uintptr:
+		return new(big.Int).SetUint64(rv.Uint()), nil
+	case float32, float64:
+		if math.IsNaN(rv.Float()) || math.IsInf(rv.Float(), 0) {
+			if err := nonFiniteErr(); err != nil {
+				return nil, err
+			}
+			return new(big.Int), nil
+		}
+		i, _ := big.NewFloat(rv.Float()).Int(nil)
+		return i, nil
+	case time.Time:
+		ts, err := time2int64(vv)
+		if err != nil {
+			return nil, err
+		}
+		return big.NewInt(ts), nil
+	}
+
+	return nil, errConvFail
+}
+
+// convert any value to *big.Float
+func convBigFloat(val interface{}) (*big.Float, error) {
+	switch vv := val.(type) {
+	case *big.Float:
+		if vv != nil {
+			return new(big.Float).Copy(vv), nil
+		}
+	case *big.Int:
+		if vv != nil {
+			return new(big.Float).SetInt(vv), nil
+		}
+	case *big.Rat:
+		if vv != nil {
+			return new(big.Float).SetRat(vv), nil
+		}
+	case json.Number:
+		return str2bigFloat(vv.String())
+	case time.Duration:
+		f, err := convFloat64E(vv)
+		return big.NewFloat(f), err
+	}
+
+	v, rv := indirectScalar(val)
+	switch vv := v.(type) {
+	case nil:
+		return new(big.Float), nil
+	case big.Float:
+		return new(big.Float).Copy(&vv), nil
+	case big.Int:
+		return new(big.Float).SetInt(&vv), nil
+	case big.Rat:
+		return new(big.Float).SetRat(&vv), nil
+	case bool:
+		if vv {
+			return big.NewFloat(1), nil
+		}
+		return new(big.Float), nil
+	case string:
+		return str2bigFloat(vv)
+	case []byte:
+		return str2bigFloat(string(vv))
+	case int, int8, int16, int32, int64:
+		return new(big.Float).SetInt64(rv.Int()), nil
+	case uint, uint8, uint16, uint32, uint64, uintptr:
+		return new(big.Float).SetUint64(rv.Uint()), nil
+	case float32, float64:
+		f, err := convFloat64E(vv)
+		if err == nil {
+			f, err = checkNonFinite(f)
+		}
+		if err == nil && math.IsNaN(f) {
+			err = errConvFail
+		}
+		if err != nil {
+			return nil, err
+		}
+		return big.NewFloat(f), nil
+	case time.Time:
+		return big.NewFloat(time2float64(vv)), nil
+	}
+
+	return nil, errConvFail
+}
+
+// apply the NonFinite policy to the ±Inf of *big.Float
+func checkNonFiniteBig(f *big.Float) (*big.Float, error) {
+	if !f.IsInf() {
+		return f, nil
+	}
+	switch NonFinite {
+	case NonFiniteReject:
+		return nil, errNonFinite
+	case NonFiniteZero:
+		return new(big.Float), nil
+	}
+	return f, nil
+}
+
+// convert any value to *big.Rat
+func convBigRat(val interface{}) (*big.Rat, error) {
+	switch vv := val.(type) {
+	case *big.Rat:
+		if vv != nil {
+			return new(big.Rat).Set(vv), nil
+		}
+	case *big.Int:
+		if vv != nil {
+			return new(big.Rat).SetInt(vv), nil
+		}
+	case *big.Float:
+		if vv != nil {
+			return bigFloat2rat(vv)
+		}
+	case json.Number:
+		return str2bigRat(vv.String())
+	case time.Duration:
+		i, err := convInt64(vv)
+		return new(big.Rat).SetInt64(i), err
+	}
+
+	v, rv := indirectScalar(val)
+	switch vv := v.(type) {
+	case nil:
+		return new(big.Rat), nil
+	case big.Rat:
+		return new(big.Rat).Set(&vv), nil
+	case big.Int:
+		return new(big.Rat).SetInt(&vv), nil
+	case big.Float:
+		return bigFloat2rat(&vv)
+	case bool:
+		if vv {
+			return big.NewRat(1, 1), nil
+		}
+		return new(big.Rat), nil
+	case string:
+		return str2bigRat(vv)
+	case []byte:
+		return str2bigRat(string(vv))
+	case int, int8, int16, int32, int64:
+		return new(big.Rat).SetInt64(rv.Int()), nil
+	case uint, uint8, uint16, uint32, uint64, uintptr:
+		return new(big.Rat).SetInt(new(big.Int).SetUint64(rv.Uint())), nil
+	case float32, float64:
+		f, err := convFloat64E(vv)
+		if err != nil {
+			return nil, err
+		}
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			if err := nonFiniteErr(); err != nil {
+				return nil, err
+			}
+			return new(big.Rat), nil
+		}
+		return new(big.Rat).SetFloat64(f), nil
+	case time.Time:
+		ts, err := time2int64(vv)
+		if err != nil {
+			return nil, err
+		}
+		return new(big.Rat).SetInt64(ts), nil
+	}
+
+	return nil, errConvFail
+}
+
+// convert an integer string of any base, or a decimal string to *big.Int
+//
+//	"0x1f" => 31
+//	"12.9" => 12
+//	"-1e3" => -1000
+func str2bigInt(s string) (*big.Int, error) {
+	if i, ok := new(big.Int).SetString(s, 0); ok {
+		return i, nil
+	}
+	if r, ok := new(big.Rat).SetString(s); ok {
+		return bigRat2int(r), nil
+	}
+	return nil, errConvFail
+}
+
+// convert a decimal string to *big.Float, with enough precision for all the digits
+func str2bigFloat(s string) (*big.Float, error) {
+	prec := uint(len(s)) * 4
+	if prec < 64 {
+		prec = 64
+	}
+	if f, ok := new(big.Float).SetPrec(prec).SetString(s); ok {
+		return f, nil
+	}
+	return nil, errConvFail
+}
+
+// convert a fraction or decimal string to *big.Rat
+func str2bigRat(s string) (*big.Rat, error) {
+	if r, ok := new(big.Rat).SetString(s); ok {
+		return r, nil
+	}
+	return nil, errConvFail
+}
+
+// returns the error of NaN and ±Inf which can't be kept, nil if it's treated as 0 by NonFinite
+func nonFiniteErr() error {
+	switch NonFinite {
+	case NonFiniteZero:
+		return nil
+	case NonFiniteReject:
+		return errNonFinite
+	}
+	return errConvFail
+}
+
+// truncate the *big.Float to *big.Int
+func bigFloat2int(f *big.Float) (*big.Int, error) {
+	if f.IsInf() {
+		if err := nonFiniteErr(); err != nil {
+			return nil, err
+		}
+		return new(big.Int), nil
+	}
+	i, _ := f.Int(nil)
+	return i, nil
+}
+
+// truncate the *big.Rat to *big.Int
+func bigRat2int(r *big.Rat) *big.Int {
+	return new(big.Int).Quo(r.Num(), r.Denom())
+}
+
+func bigFloat2rat(f *big.Float) (*big.Rat, error) {
+	if f.IsInf() {
+		if err := nonFiniteErr(); err != nil {
+			return nil, err
+		}
+		return new(big.Rat), nil
+	}
+	r, _ := f.Rat(nil)
+	return r, nil
+}
+
+// convert *big.Int to int64, reports the overflow
+func bigInt2int64(i *big.Int) (int64, error) {
+	if !i.IsInt64() {
+		return 0, fmt.Errorf("value %s out of range of int64", i.String())
+	}
+	return i.Int64(), nil
+}
+
+// convert *big.Int to uint64, reports the overflow
+func bigInt2uint64(i *big.Int) (uint64, error) {
+	if !i.IsUint64() {
+		return 0, fmt.Errorf("value %s out of range of uint64", i.String())
+	}
+	return i.Uint64(), nil
+}
+
+// convert *big.Float to float64, reports the overflow
+func bigFloat2float64(f *big.Float) (float64, error) {
+	v, _ := f.Float64()
+	if math.IsInf(v, 0) && !f.IsInf() {
+		return 0, fmt.Errorf("value %s out of range of float64", f.Text('g', 10))
+	}
+	return v, nil
+}
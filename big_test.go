@@ -0,0 +1,307 @@
+package cvt_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shockerli/cvt"
+)
+
+var (
+	bigIntMaxUint128, _ = new(big.Int).SetString("340282366920938463463374607431768211455", 10)
+	bigFloat1d5         = big.NewFloat(1.5)
+	bigRat3d4           = big.NewRat(3, 4)
+)
+
+func TestBigInt_HasDefault(t *testing.T) {
+	tests := []struct {
+		input  interface{}
+		def    *big.Int
+		expect string
+	}{
+		// supported value, def is not used, def != expect
+		{"12", big.NewInt(1), "12"},
+		{nil, big.NewInt(1), "0"},
+
+		// unsupported value, def == expect
+		{"hello", big.NewInt(1), "1"},
+		{math.NaN(), big.NewInt(1), "1"},
+		{testing.T{}, big.NewInt(1), "1"},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, input[%+v], def[%+v], expect[%+v]", i, tt.input, tt.def, tt.expect)
+
+		v := cvt.BigInt(tt.input, tt.def)
+		assertEqual(t, tt.expect, v.String(), "[NonE] "+msg)
+	}
+
+	assertEqual(t, "0", cvt.BigInt("hello").String())
+}
+
+func TestBigIntE(t *testing.T) {
+	tests := []struct {
+		input  interface{}
+		expect string
+		isErr  bool
+	}{
+		{nil, "0", false},
+		{true, "1", false},
+		{false, "0", false},
+		{12, "12", false},
+		{int8(-12), "-12", false},
+		{uint64(math.MaxUint64), "18446744073709551615", false},
+		{12.9, "12", false},
+		{float32(-12.9), "-12", false},
+		{1e30, "1000000000000000019884624838656", false},
+		{"340282366920938463463374607431768211455", "340282366920938463463374607431768211455", false},
+		{"-12", "-12", false},
+		{"0x1f", "31", false},
+		{"0b101", "5", false},
+		{"0o17", "15", false},
+		{"0xffffffffffffffffffffffffffffffff", "340282366920938463463374607431768211455", false},
+		{"12.9", "12", false},
+		{"-1e3", "-1000", false},
+		{"3/2", "1", false},
+		{[]byte("12"), "12", false},
+		{json.Number("123456789012345678901234567890"), "123456789012345678901234567890", false},
+		{AliasTypeString("12"), "12", false},
+		{&aliasTypeInt1, "1", false},
+		{pointerIntNil, "0", false},
+		{bigIntMaxUint128, "340282366920938463463374607431768211455", false},
+		{*bigIntMaxUint128, "340282366920938463463374607431768211455", false},
+		{(*big.Int)(nil), "0", false},
+		{bigFloat1d5, "1", false},
+		{*bigFloat1d5, "1", false},
+		{bigRat3d4, "0", false},
+		{big.NewRat(-7, 2), "-3", false},
+		{*big.NewRat(7, 2), "3", false},
+		{time1, "1234567890", false},
+		{time.Second, "1000000000", false},
+
+		// errors
+		{"hello", "", true},
+		{"", "", true},
+		{math.NaN(), "", true},
+		{math.Inf(1), "", true},
+		{new(big.Float).SetInf(false), "", true},
+		{*new(big.Float).SetInf(true), "", true},
+		{json.Number("hello"), "", true},
+		{testing.T{}, "", true},
+		{[]int{1}, "", true},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, input[%+v], expect[%+v], isErr[%v]", i, tt.input, tt.expect, tt.isErr)
+
+		v, err := cvt.BigIntE(tt.input)
+		if tt.isErr {
+			assertError(t, err, "[HasErr] "+msg)
+			continue
+		}
+
+		assertNoError(t, err, "[NoErr] "+msg)
+		assertEqual(t, tt.expect, v.String(), "[WithE] "+msg)
+
+		// Non-E test
+		v = cvt.BigInt(tt.input)
+		assertEqual(t, tt.expect, v.String(), "[NonE] "+msg)
+	}
+
+	// returns a copy
+	v := cvt.BigInt(bigIntMaxUint128)
+	v.SetInt64(0)
+	assertEqual(t, "340282366920938463463374607431768211455", bigIntMaxUint128.String())
+}
+
+func TestBigFloatE(t *testing.T) {
+	tests := []struct {
+		input  interface{}
+		expect string
+		isErr  bool
+	}{
+		{nil, "0", false},
+		{true, "1", false},
+		{false, "0", false},
+		{12, "12", false},
+		{uint64(math.MaxUint64), "18446744073709551615", false},
+		{-12.5, "-12.5", false},
+		{float32(8.31), "8.31", false},
+		{math.Inf(-1), "-Inf", false},
+		{"0.1", "0.1", false},
+		{"12345678901234567890.123456789", "12345678901234567890.123456789", false},
+		{"1e-3", "0.001", false},
+		{"Inf", "+Inf", false},
+		{[]byte("12.5"), "12.5", false},
+		{json.Number("12.5"), "12.5", false},
+		{AliasTypeFloat64(12.5), "12.5", false},
+		{bigIntMaxUint128, "340282366920938463463374607431768211455", false},
+		{*bigIntMaxUint128, "340282366920938463463374607431768211455", false},
+		{bigFloat1d5, "1.5", false},
+		{*bigFloat1d5, "1.5", false},
+		{bigRat3d4, "0.75", false},
+		{*bigRat3d4, "0.75", false},
+		{time1.Add(5e8), "1234567890.5", false},
+		{time.Duration(12), "12", false},
+
+		// errors
+		{"hello", "", true},
+		{"3/4", "", true},
+		{math.NaN(), "", true},
+		{json.Number("hello"), "", true},
+		{testing.T{}, "", true},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, input[%+v], expect[%+v], isErr[%v]", i, tt.input, tt.expect, tt.isErr)
+
+		v, err := cvt.BigFloatE(tt.input)
+		if tt.isErr {
+			assertError(t, err, "[HasErr] "+msg)
+			continue
+		}
+
+		assertNoError(t, err, "[NoErr] "+msg)
+		assertEqual(t, tt.expect, v.Text('f', -1), "[WithE] "+msg)
+
+		// Non-E test
+		v = cvt.BigFloat(tt.input)
+		assertEqual(t, tt.expect, v.Text('f', -1), "[NonE] "+msg)
+	}
+
+	assertEqual(t, "1", cvt.BigFloat("hello", big.NewFloat(1)).String())
+	assertEqual(t, "0", cvt.BigFloat("hello").String())
+}
+
+func TestBigRatE(t *testing.T) {
+	tests := []struct {
+		input  interface{}
+		expect string
+		isErr  bool
+	}{
+		{nil, "0/1", false},
+		{true, "1/1", false},
+		{false, "0/1", false},
+		{12, "12/1", false},
+		{uint64(math.MaxUint64), "18446744073709551615/1", false},
+		{1.5, "3/2", false},
+		{float32(0.5), "1/2", false},
+		{"3/4", "3/4", false},
+		{"1.25", "5/4", false},
+		{"1e-3", "1/1000", false},
+		{"0.1", "1/10", false},
+		{[]byte("0.1"), "1/10", false},
+		{json.Number("0.1"), "1/10", false},
+		{bigIntMaxUint128, "340282366920938463463374607431768211455/1", false},
+		{*bigIntMaxUint128, "340282366920938463463374607431768211455/1", false},
+		{bigFloat1d5, "3/2", false},
+		{*bigFloat1d5, "3/2", false},
+		{bigRat3d4, "3/4", false},
+		{*bigRat3d4, "3/4", false},
+		{time1, "1234567890/1", false},
+		{time.Duration(12), "12/1", false},
+
+		// errors
+		{"hello", "", true},
+		{math.NaN(), "", true},
+		{math.Inf(1), "", true},
+		{new(big.Float).SetInf(false), "", true},
+		{*new(big.Float).SetInf(false), "", true},
+		{testing.T{}, "", true},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, input[%+v], expect[%+v], isErr[%v]", i, tt.input, tt.expect, tt.isErr)
+
+		v, err := cvt.BigRatE(tt.input)
+		if tt.isErr {
+			assertError(t, err, "[HasErr] "+msg)
+			continue
+		}
+
+		assertNoError(t, err, "[NoErr] "+msg)
+		assertEqual(t, tt.expect, v.String(), "[WithE] "+msg)
+
+		// Non-E test
+		v = cvt.BigRat(tt.input)
+		assertEqual(t, tt.expect, v.String(), "[NonE] "+msg)
+	}
+
+	assertEqual(t, "1/1", cvt.BigRat("hello", big.NewRat(1, 1)).String())
+	assertEqual(t, "0/1", cvt.BigRat("hello").String())
+}
+
+func TestBig_NumericConverters(t *testing.T) {
+	var maxInt64 = big.NewInt(math.MaxInt64)
+	var overInt64 = new(big.Int).Add(maxInt64, big.NewInt(1))
+
+	v, err := cvt.Int64E(maxInt64)
+	assertNoError(t, err)
+	assertEqual(t, int64(math.MaxInt64), v)
+	v, err = cvt.Int64E(*maxInt64)
+	assertNoError(t, err)
+	assertEqual(t, int64(math.MaxInt64), v)
+	v, err = cvt.Int64E(big.NewFloat(-12.5))
+	assertNoError(t, err)
+	assertEqual(t, int64(-12), v)
+	v, err = cvt.Int64E(big.NewRat(7, 2))
+	assertNoError(t, err)
+	assertEqual(t, int64(3), v)
+
+	_, err = cvt.Int64E(overInt64)
+	assertError(t, err)
+	assertEqual(t, true, strings.Contains(err.Error(), "9223372036854775808 out of range of int64"), err.Error())
+	_, err = cvt.Int64E(*overInt64)
+	assertError(t, err)
+	_, err = cvt.Int64E(new(big.Float).SetInf(false))
+	assertError(t, err)
+
+	u, err := cvt.Uint64E(overInt64)
+	assertNoError(t, err)
+	assertEqual(t, uint64(math.MaxInt64)+1, u)
+	u, err = cvt.Uint64E(*overInt64)
+	assertNoError(t, err)
+	assertEqual(t, uint64(math.MaxInt64)+1, u)
+	_, err = cvt.Uint64E(big.NewInt(-1))
+	assertError(t, err)
+	assertEqual(t, true, strings.Contains(err.Error(), "-1 out of range of uint64"), err.Error())
+	_, err = cvt.Uint64E(bigIntMaxUint128)
+	assertError(t, err)
+	_, err = cvt.Uint64E(*bigIntMaxUint128)
+	assertError(t, err)
+	_, err = cvt.Uint64E(new(big.Float).SetInf(false))
+	assertError(t, err)
+
+	i32, err := cvt.Int32E(big.NewInt(12))
+	assertNoError(t, err)
+	assertEqual(t, int32(12), i32)
+	_, err = cvt.Int32E(big.NewInt(math.MaxInt64))
+	assertError(t, err)
+
+	f, err := cvt.Float64E(bigRat3d4)
+	assertNoError(t, err)
+	assertEqual(t, 0.75, f)
+	f, err = cvt.Float64E(*bigFloat1d5)
+	assertNoError(t, err)
+	assertEqual(t, 1.5, f)
+	f, err = cvt.Float64E(bigIntMaxUint128)
+	assertNoError(t, err)
+	assertEqual(t, 3.402823669209385e+38, f)
+	_, err = cvt.Float64E(new(big.Float).SetMantExp(big.NewFloat(1), 2000))
+	assertError(t, err)
+	assertEqual(t, true, strings.Contains(err.Error(), "out of range of float64"), err.Error())
+	_, err = cvt.Float64E(*new(big.Float).SetMantExp(big.NewFloat(1), 2000))
+	assertError(t, err)
+
+	s, err := cvt.StringE(big.NewFloat(0.1))
+	assertNoError(t, err)
+	assertEqual(t, "0.1", s)
+	s, err = cvt.StringE(cvt.BigFloat("12345678901234567890.123456789"))
+	assertNoError(t, err)
+	assertEqual(t, "12345678901234567890.123456789", s)
+}
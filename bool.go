@@ -2,9 +2,11 @@ package cvt
 
 import (
 	"encoding/json"
+	"fmt"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -35,12 +37,12 @@ func BoolE(val interface{}) (bool, error) {
 		return false, nil
 	case bool:
 		return vv, nil
-	case
-		float32, float64:
-		return Float64(vv) != 0, nil
-	case
-		time.Duration,
-		int, int8, int16, int32, int64:
+	case float32, float64:
+		f, _ := convFloat64E(vv)
+		return float2bool(f, val)
+	case time.Duration:
+		return vv != 0, nil
+	case int, int8, int16, int32, int64:
 		return Int64(vv) != 0, nil
 	case uint, uint8, uint16, uint32, uint64:
 		return Uint64(vv) != 0, nil
@@ -53,11 +55,11 @@ func BoolE(val interface{}) (bool, error) {
 		if err != nil {
 			return false, newErr(val, "bool")
 		}
-		return vvv != 0, nil
+		return float2bool(vvv, val)
 	}
 
 	// indirect type
-	v, rv := Indirect(val)
+	v, rv := indirectScalar(val)
 
 	switch vv := v.(type) {
 	case nil:
@@ -69,7 +71,7 @@ func BoolE(val interface{}) (bool, error) {
 	case uint, uint8, uint16, uint32, uint64:
 		return rv.Uint() != 0, nil
 	case float32, float64:
-		return rv.Float() != 0, nil
+		return float2bool(rv.Float(), val)
 	case []byte:
 		return str2bool(string(vv))
 	case string:
@@ -87,18 +89,179 @@ func BoolE(val interface{}) (bool, error) {
 
 // returns the boolean value represented by the string
 func str2bool(str string) (bool, error) {
-	if val, err := strconv.ParseBool(str); err == nil {
+	s := BoolWords.normalize(str)
+	if val, err := strconv.ParseBool(s); err == nil {
+		return val, nil
+	} else if val, err := strconv.ParseFloat(s, 64); err == nil {
+		return float2bool(val, str)
+	} else if val, ok := BoolWords.lookup(s); ok {
 		return val, nil
-	} else if val, err := strconv.ParseFloat(str, 64); err == nil {
-		return val != 0, nil
 	}
 
-	switch strings.ToLower(strings.TrimSpace(str)) {
-	case "on", "yes", "y":
-		return true, nil
-	case "off", "no", "n":
-		return false, nil
-	default:
-		return false, newErr(str, "bool")
+	return false, newErr(str, "bool")
+}
+
+// returns the float is not 0, NaN and ±Inf are handled by NonFinite
+func float2bool(f float64, val interface{}) (bool, error) {
+	f, err := checkNonFinite(f)
+	if err != nil {
+		return false, fmt.Errorf(formatExtend, newErr(val, "bool"), err)
+	}
+	return f != 0, nil
+}
+
+// BoolWords the vocabulary of true/false words used by BoolE,
+// in addition to strconv.ParseBool and numbers
+// you can register your custom words, or the words of the locale presets
+var BoolWords = NewBoolVocabulary()
+
+// BoolPreset the true/false words of a locale
+type BoolPreset struct {
+	True  []string
+	False []string
+}
+
+// the preset words by locale, guarded by boolPresetsMu
+var boolPresetsMu sync.RWMutex
+var boolPresets = map[string]BoolPreset{
+	"en": {
+		True:  []string{"yes", "y", "on", "enable", "enabled", "checked", "active"},
+		False: []string{"no", "n", "off", "disable", "disabled", "unchecked", "inactive"},
+	},
+	"zh": {
+		True:  []string{"是", "对", "真", "开", "开启", "启用", "已选"},
+		False: []string{"否", "错", "假", "关", "关闭", "禁用", "未选"},
+	},
+	"de": {
+		True:  []string{"ja", "j", "wahr", "an", "ein"},
+		False: []string{"nein", "falsch", "aus"},
+	},
+	"fr": {
+		True:  []string{"oui", "o", "vrai", "activé"},
+		False: []string{"non", "faux", "désactivé"},
+	},
+	"es": {
+		True:  []string{"sí", "si", "s", "verdadero"},
+		False: []string{"no", "falso"},
+	},
+	"ja": {
+		True:  []string{"はい", "真", "オン"},
+		False: []string{"いいえ", "偽", "オフ"},
+	},
+}
+
+// RegisterBoolPreset add or replace the preset words of the locale, safe for concurrent use
+// the preset can be registered to a vocabulary by BoolVocabulary.RegisterPreset
+func RegisterBoolPreset(locale string, p BoolPreset) {
+	p = BoolPreset{
+		True:  append([]string(nil), p.True...),
+		False: append([]string(nil), p.False...),
+	}
+	boolPresetsMu.Lock()
+	boolPresets[locale] = p
+	boolPresetsMu.Unlock()
+}
+
+// BoolVocabulary the set of true/false words, safe for concurrent use
+// by default, the words are case-insensitive, and the spaces around are ignored
+type BoolVocabulary struct {
+	mu        sync.RWMutex
+	words     map[string]bool // the registered words
+	folded    map[string]bool // the lower case of registered words
+	foldCase  bool
+	trimSpace bool
+}
+
+// NewBoolVocabulary returns a vocabulary with words: "on", "yes", "y", "off", "no", "n"
+func NewBoolVocabulary() *BoolVocabulary {
+	v := &BoolVocabulary{
+		words:     make(map[string]bool),
+		folded:    make(map[string]bool),
+		foldCase:  true,
+		trimSpace: true,
+	}
+	v.Register(true, "on", "yes", "y")
+	v.Register(false, "off", "no", "n")
+	return v
+}
+
+// Register add the words represent the bool value,
+// the word registered later overwrites the earlier one
+func (v *BoolVocabulary) Register(val bool, words ...string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for _, w := range words {
+		w = strings.TrimSpace(w)
+		v.words[w] = val
+		v.folded[strings.ToLower(w)] = val
+	}
+}
+
+// RegisterPreset add the preset words of the locale, see RegisterBoolPreset
+// the built-in locales: en, zh, de, fr, es, ja
+func (v *BoolVocabulary) RegisterPreset(locale string) error {
+	boolPresetsMu.RLock()
+	p, ok := boolPresets[locale]
+	boolPresetsMu.RUnlock()
+	if !ok {
+		return fmt.Errorf("unknown bool preset: %s", locale)
+	}
+	v.Register(true, p.True...)
+	v.Register(false, p.False...)
+	return nil
+}
+
+// Unregister remove the words, and the same words in other case
+func (v *BoolVocabulary) Unregister(words ...string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for _, w := range words {
+		w = strings.ToLower(strings.TrimSpace(w))
+		delete(v.folded, w)
+		for k := range v.words {
+			if strings.ToLower(k) == w {
+				delete(v.words, k)
+			}
+		}
+	}
+}
+
+// SetFoldCase set whether the words are case-insensitive
+func (v *BoolVocabulary) SetFoldCase(fold bool) {
+	v.mu.Lock()
+	v.foldCase = fold
+	v.mu.Unlock()
+}
+
+// SetTrimSpace set whether the spaces around the string are ignored
+func (v *BoolVocabulary) SetTrimSpace(trim bool) {
+	v.mu.Lock()
+	v.trimSpace = trim
+	v.mu.Unlock()
+}
+
+// Lookup returns the bool value of the word, and whether the word is registered
+func (v *BoolVocabulary) Lookup(word string) (val bool, ok bool) {
+	return v.lookup(v.normalize(word))
+}
+
+// apply the trimming rule, the case is folded while lookup
+func (v *BoolVocabulary) normalize(s string) string {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.trimSpace {
+		return strings.TrimSpace(s)
+	}
+	return s
+}
+
+func (v *BoolVocabulary) lookup(s string) (val bool, ok bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.foldCase {
+		val, ok = v.folded[strings.ToLower(s)]
+	} else {
+		val, ok = v.words[s]
 	}
+	return
 }
@@ -3,6 +3,7 @@ package cvt_test
 import (
 	"encoding/json"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -212,6 +213,8 @@ func TestBoolE(t *testing.T) {
 		{-1, true, false},
 		{"on", true, false},
 		{"On", true, false},
+		{" T ", true, false},
+		{"\tyes\n", true, false},
 		{0.01, true, false},
 		{"0.01", true, false},
 		{[]byte("true"), true, false},
@@ -267,3 +270,152 @@ func TestBoolE(t *testing.T) {
 		assertEqual(t, tt.expect, v, "[NonE] "+msg)
 	}
 }
+
+func TestBoolVocabulary(t *testing.T) {
+	v := cvt.NewBoolVocabulary()
+	assertNoError(t, v.RegisterPreset("zh"))
+	assertNoError(t, v.RegisterPreset("de"))
+	assertError(t, v.RegisterPreset("xx"))
+	v.Register(true, "Checked", " T ")
+	v.Register(false, "F")
+
+	tests := []struct {
+		input  string
+		expect bool
+		ok     bool
+	}{
+		{"是", true, true},
+		{"否", false, true},
+		{"ja", true, true},
+		{"JA", true, true},
+		{" nein ", false, true},
+		{"checked", true, true},
+		{"CHECKED", true, true},
+		{"t", true, true},
+		{"f", false, true},
+		{"yes", true, true},
+		{"off", false, true},
+		{"enabled", false, false},
+		{"hello", false, false},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, input[%+v], expect[%+v], ok[%+v]", i, tt.input, tt.expect, tt.ok)
+
+		val, ok := v.Lookup(tt.input)
+		assertEqual(t, tt.ok, ok, msg)
+		assertEqual(t, tt.expect, val, msg)
+	}
+
+	// case sensitive
+	v.SetFoldCase(false)
+	_, ok := v.Lookup("JA")
+	assertEqual(t, false, ok)
+	val, ok := v.Lookup("Checked")
+	assertEqual(t, true, ok)
+	assertEqual(t, true, val)
+	v.SetFoldCase(true)
+
+	// keep the spaces
+	v.SetTrimSpace(false)
+	_, ok = v.Lookup(" ja ")
+	assertEqual(t, false, ok)
+	v.SetTrimSpace(true)
+
+	// unregister all cases of the word
+	v.Unregister("CHECKED", "y")
+	_, ok = v.Lookup("Checked")
+	assertEqual(t, false, ok)
+	_, ok = v.Lookup("y")
+	assertEqual(t, false, ok)
+}
+
+func TestRegisterBoolPreset(t *testing.T) {
+	words := []string{"ya", "yep"}
+	cvt.RegisterBoolPreset("test-slang", cvt.BoolPreset{True: words, False: []string{"nah"}})
+	words[0] = "changed" // the preset is copied
+
+	v := cvt.NewBoolVocabulary()
+	assertNoError(t, v.RegisterPreset("test-slang"))
+	for _, w := range []string{"ya", "Yep", "nah"} {
+		_, ok := v.Lookup(w)
+		assertEqual(t, true, ok, w)
+	}
+	_, ok := v.Lookup("changed")
+	assertEqual(t, false, ok)
+
+	// concurrent registration and use
+	var wg sync.WaitGroup
+	for j := 0; j < 8; j++ {
+		wg.Add(2)
+		go func(j int) {
+			defer wg.Done()
+			cvt.RegisterBoolPreset(fmt.Sprintf("test-%d", j), cvt.BoolPreset{True: []string{"t"}})
+		}(j)
+		go func() {
+			defer wg.Done()
+			assertNoError(t, cvt.NewBoolVocabulary().RegisterPreset("en"))
+		}()
+	}
+	wg.Wait()
+}
+
+func TestBoolE_BoolWords(t *testing.T) {
+	defer func(v *cvt.BoolVocabulary) {
+		cvt.BoolWords = v
+	}(cvt.BoolWords)
+
+	cvt.BoolWords = cvt.NewBoolVocabulary()
+	assertNoError(t, cvt.BoolWords.RegisterPreset("en"))
+	assertNoError(t, cvt.BoolWords.RegisterPreset("zh"))
+	cvt.BoolWords.Unregister("n")
+
+	tests := []struct {
+		input  interface{}
+		expect bool
+		isErr  bool
+	}{
+		{"是", true, false},
+		{[]byte("否"), false, false},
+		{"Enabled", true, false},
+		{" disabled ", false, false},
+		{AliasTypeString("checked"), true, false},
+		{"true", true, false},
+		{"0", false, false},
+
+		// errors
+		{"n", false, true},
+		{"ja", false, true},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, input[%+v], expect[%+v], isErr[%+v]", i, tt.input, tt.expect, tt.isErr)
+
+		v, err := cvt.BoolE(tt.input)
+		if tt.isErr {
+			assertError(t, err, "[HasErr] "+msg)
+			continue
+		}
+
+		assertNoError(t, err, "[NoErr] "+msg)
+		assertEqual(t, tt.expect, v, "[WithE] "+msg)
+	}
+}
+
+func TestBoolVocabulary_Concurrent(t *testing.T) {
+	v := cvt.NewBoolVocabulary()
+	done := make(chan struct{})
+	for j := 0; j < 4; j++ {
+		go func(j int) {
+			defer func() { done <- struct{}{} }()
+			for k := 0; k < 100; k++ {
+				v.Register(j%2 == 0, fmt.Sprintf("w%d-%d", j, k))
+				v.Lookup(fmt.Sprintf("w%d-%d", j, k))
+				v.SetFoldCase(k%2 == 0)
+			}
+		}(j)
+	}
+	for j := 0; j < 4; j++ {
+		<-done
+	}
+}
@@ -0,0 +1,189 @@
+package cvt
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Complex128 convert an interface to a complex128 type, with default value
+func Complex128(v interface{}, def ...complex128) complex128 {
+	if v, err := Complex128E(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return 0
+}
+
+// Complex128E convert an interface to a complex128 type
+// * string of complex, eg: "3+4i", "(3+4i)", "4i"
+// * real number is promoted, eg: 3 => (3+0i)
+// * pair of real and imaginary part, eg: [2]float64{3, 4}
+// * map with key "re"/"real" and "im"/"imag", eg: {"re": 3, "im": 4}
+// * NaN and ±Inf of the real and imaginary part are handled by NonFinite
+func Complex128E(val interface{}) (complex128, error) {
+	v, e := convComplex128(val)
+	if e == nil {
+		v, e = checkNonFiniteComplex(v)
+	}
+	if e := catch("complex128", val, e); e != nil {
+		return 0, e
+	}
+
+	return v, nil
+}
+
+// Complex64 convert an interface to a complex64 type, with default value
+func Complex64(v interface{}, def ...complex64) complex64 {
+	if v, err := Complex64E(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return 0
+}
+
+// Complex64E convert an interface to a complex64 type
+func Complex64E(val interface{}) (complex64, error) {
+	v, e := convComplex128(val)
+	if e == nil {
+		v, e = checkNonFiniteComplex(v)
+	}
+	if e := catch("complex64", val, e); e != nil {
+		return 0, e
+	}
+	if math.Abs(real(v)) > math.MaxFloat32 || math.Abs(imag(v)) > math.MaxFloat32 {
+		if !math.IsInf(real(v), 0) && !math.IsInf(imag(v), 0) {
+			return 0, fmt.Errorf(formatOutOfLimitFloat, newErr(val, "complex64"), float32(math.MaxFloat32))
+		}
+	}
+
+	return complex64(v), nil
+}
+
+// convert any value to complex128
+func convComplex128(val interface{}) (complex128, error) {
+	// direct type(for improve performance)
+	switch vv := val.(type) {
+	case nil:
+		return 0, nil
+	case complex128:
+		return vv, nil
+	case complex64:
+		return complex128(vv), nil
+	case string:
+		return parseComplex(vv, 128)
+	case []byte:
+		return parseComplex(string(vv), 128)
+	case json.Number:
+		return parseComplex(vv.String(), 128)
+	}
+
+	// indirect type
+	v, rv := indirectScalar(val)
+	switch vv := v.(type) {
+	case nil:
+		return 0, nil
+	case complex128:
+		return vv, nil
+	case complex64:
+		return complex128(vv), nil
+	case string:
+		return parseComplex(vv, 128)
+	case []byte:
+		return parseComplex(string(vv), 128)
+	}
+
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		// pair of real and imaginary part
+		if rv.Len() != 2 {
+			return 0, errConvFail
+		}
+		re, err := convFloat64E(rv.Index(0).Interface())
+		if err != nil {
+			return 0, err
+		}
+		im, err := convFloat64E(rv.Index(1).Interface())
+		if err != nil {
+			return 0, err
+		}
+		return complex(re, im), nil
+	case reflect.Map:
+		return map2complex(rv)
+	}
+
+	// real number
+	f, err := convFloat64E(v)
+	return complex(f, 0), err
+}
+
+// apply the NonFinite policy to the real and imaginary part
+func checkNonFiniteComplex(c complex128) (complex128, error) {
+	re, err := checkNonFinite(real(c))
+	if err != nil {
+		return 0, err
+	}
+	im, err := checkNonFinite(imag(c))
+	if err != nil {
+		return 0, err
+	}
+	return complex(re, im), nil
+}
+
+// convert the map with key "re"/"real" and "im"/"imag" to complex128
+func map2complex(rv reflect.Value) (complex128, error) {
+	var re, im float64
+	var found bool
+	for _, key := range rv.MapKeys() {
+		var part *float64
+		switch strings.ToLower(String(key.Interface())) {
+		case "re", "real":
+			part = &re
+		case "im", "imag":
+			part = &im
+		default:
+			continue
+		}
+		f, err := convFloat64E(rv.MapIndex(key).Interface())
+		if err != nil {
+			return 0, err
+		}
+		*part, found = f, true
+	}
+	if !found {
+		return 0, errConvFail
+	}
+	return complex(re, im), nil
+}
+
+// format the complex, NaN and ±Inf of the real and imaginary part are handled by NonFinite
+func formatComplexE(c complex128, bitSize int) (string, error) {
+	c, err := checkNonFiniteComplex(c)
+	if err != nil {
+		return "", err
+	}
+	return formatComplex(c, bitSize), nil
+}
+
+// format the complex, same as strconv.FormatComplex(c, 'f', -1, bitSize)
+//
+//	(3+4i) => "(3+4i)"
+func formatComplex(c complex128, bitSize int) string {
+	size := bitSize / 2
+	im := strconv.FormatFloat(imag(c), 'f', -1, size)
+	if im[0] != '+' && im[0] != '-' {
+		im = "+" + im
+	}
+	return "(" + strconv.FormatFloat(real(c), 'f', -1, size) + im + "i)"
+}
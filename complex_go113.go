@@ -0,0 +1,37 @@
+//go:build !go1.15
+// +build !go1.15
+
+package cvt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parse the complex string, eg: "3+4i", "(3+4i)", "4i", "3"
+// strconv.ParseComplex is unavailable before go1.15
+func parseComplex(s string, bitSize int) (complex128, error) {
+	if len(s) >= 2 && s[0] == '(' && s[len(s)-1] == ')' {
+		s = s[1 : len(s)-1]
+	}
+	size := bitSize / 2
+
+	// real only
+	if f, err := strconv.ParseFloat(s, size); err == nil {
+		return complex(f, 0), nil
+	}
+	// imaginary only
+	if strings.HasSuffix(s, "i") {
+		if f, err := strconv.ParseFloat(s[:len(s)-1], size); err == nil {
+			return complex(0, f), nil
+		}
+	}
+
+	var c complex128
+	var rest string
+	if n, _ := fmt.Sscan(s, &c, &rest); n != 1 {
+		return 0, errConvFail
+	}
+	return c, nil
+}
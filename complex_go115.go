@@ -0,0 +1,11 @@
+//go:build go1.15
+// +build go1.15
+
+package cvt
+
+import "strconv"
+
+// parse the complex string, eg: "3+4i", "(3+4i)", "4i", "3"
+func parseComplex(s string, bitSize int) (complex128, error) {
+	return strconv.ParseComplex(s, bitSize)
+}
@@ -0,0 +1,177 @@
+package cvt_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/shockerli/cvt"
+)
+
+func TestComplex128_HasDefault(t *testing.T) {
+	tests := []struct {
+		input  interface{}
+		def    complex128
+		expect complex128
+	}{
+		// supported value, def is not used, def != expect
+		{"3+4i", 1i, 3 + 4i},
+		{3, 1i, 3},
+
+		// unsupported value, def == expect
+		{"hello", 1i, 1i},
+		{[]int{1, 2, 3}, 1i, 1i},
+		{testing.T{}, 1i, 1i},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, input[%+v], def[%+v], expect[%+v]", i, tt.input, tt.def, tt.expect)
+
+		v := cvt.Complex128(tt.input, tt.def)
+		assertEqual(t, tt.expect, v, "[NonE] "+msg)
+	}
+}
+
+func TestComplex128E(t *testing.T) {
+	var c = AliasTypeComplex128(3 + 4i)
+
+	tests := []struct {
+		input  interface{}
+		expect complex128
+		isErr  bool
+	}{
+		{nil, 0, false},
+		{3 + 4i, 3 + 4i, false},
+		{complex64(3 + 4i), 3 + 4i, false},
+		{c, 3 + 4i, false},
+		{&c, 3 + 4i, false},
+
+		// string
+		{"3+4i", 3 + 4i, false},
+		{"(3+4i)", 3 + 4i, false},
+		{"-1.5e3-2i", -1500 - 2i, false},
+		{"4i", 4i, false},
+		{"3", 3, false},
+		{[]byte("3+4i"), 3 + 4i, false},
+		{json.Number("3"), 3, false},
+		{AliasTypeString("3+4i"), 3 + 4i, false},
+		{AliasTypeBytes("3+4i"), 3 + 4i, false},
+
+		// real number
+		{3, 3, false},
+		{uint8(3), 3, false},
+		{-3.5, -3.5, false},
+		{float32(8.31), 8.31, false},
+		{true, 1, false},
+		{aliasTypeInt1, 1, false},
+		{&aliasTypeInt1, 1, false},
+		{pointerIntNil, 0, false},
+
+		// pair
+		{[2]float64{3, 4}, 3 + 4i, false},
+		{[]interface{}{"3", 4}, 3 + 4i, false},
+		{[]string{"3", "-4"}, 3 - 4i, false},
+
+		// map
+		{map[string]interface{}{"re": 3, "im": 4}, 3 + 4i, false},
+		{map[string]float64{"real": 3, "imag": 4}, 3 + 4i, false},
+		{map[string]string{"RE": "3"}, 3, false},
+		{map[interface{}]interface{}{"im": 4, "other": "x"}, 4i, false},
+
+		// errors
+		{"hello", 0, true},
+		{"3+4j", 0, true},
+		{"3+4i junk", 0, true},
+		{[]int{1, 2, 3}, 0, true},
+		{[]string{"a", "4"}, 0, true},
+		{[]string{"3", "b"}, 0, true},
+		{map[string]int{"x": 1}, 0, true},
+		{map[string]string{"re": "a"}, 0, true},
+		{testing.T{}, 0, true},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, input[%+v], expect[%+v], isErr[%v]", i, tt.input, tt.expect, tt.isErr)
+
+		v, err := cvt.Complex128E(tt.input)
+		if tt.isErr {
+			assertError(t, err, "[HasErr] "+msg)
+			continue
+		}
+
+		assertNoError(t, err, "[NoErr] "+msg)
+		assertEqual(t, tt.expect, v, "[WithE] "+msg)
+
+		// Non-E test
+		v = cvt.Complex128(tt.input)
+		assertEqual(t, tt.expect, v, "[NonE] "+msg)
+	}
+}
+
+func TestComplex64E(t *testing.T) {
+	tests := []struct {
+		input  interface{}
+		expect complex64
+		isErr  bool
+	}{
+		{nil, 0, false},
+		{3 + 4i, 3 + 4i, false},
+		{"3+4i", 3 + 4i, false},
+		{3, 3, false},
+		{[2]float64{3, 4}, 3 + 4i, false},
+		{complex(math.Inf(1), 0), complex64(complex(math.Inf(1), 0)), false},
+
+		// errors
+		{"hello", 0, true},
+		{complex(math.MaxFloat64, 0), 0, true},
+		{complex(0, -math.MaxFloat64), 0, true},
+		{testing.T{}, 0, true},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, input[%+v], expect[%+v], isErr[%v]", i, tt.input, tt.expect, tt.isErr)
+
+		v, err := cvt.Complex64E(tt.input)
+		if tt.isErr {
+			assertError(t, err, "[HasErr] "+msg)
+			continue
+		}
+
+		assertNoError(t, err, "[NoErr] "+msg)
+		assertEqual(t, tt.expect, v, "[WithE] "+msg)
+
+		// Non-E test
+		v = cvt.Complex64(tt.input)
+		assertEqual(t, tt.expect, v, "[NonE] "+msg)
+	}
+
+	assertEqual(t, complex64(1i), cvt.Complex64("hello", 1i))
+	assertEqual(t, complex64(0), cvt.Complex64("hello"))
+}
+
+func TestStringE_Complex(t *testing.T) {
+	var c = AliasTypeComplex64(1.5 - 2i)
+
+	tests := []struct {
+		input  interface{}
+		expect string
+	}{
+		{3 + 4i, "(3+4i)"},
+		{complex64(3 + 4i), "(3+4i)"},
+		{-1.5 - 2i, "(-1.5-2i)"},
+		{complex64(0.1 + 0.2i), "(0.1+0.2i)"},
+		{complex(math.Inf(1), math.NaN()), "(+Inf+NaNi)"},
+		{c, "(1.5-2i)"},
+		{&c, "(1.5-2i)"},
+		{AliasTypeComplex128(1e21), "(1000000000000000000000+0i)"},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, input[%+v], expect[%+v]", i, tt.input, tt.expect)
+
+		v, err := cvt.StringE(tt.input)
+		assertNoError(t, err, "[NoErr] "+msg)
+		assertEqual(t, tt.expect, v, "[WithE] "+msg)
+	}
+}
@@ -1,11 +1,16 @@
 package cvt
 
 import (
+	"database/sql/driver"
+	"encoding"
 	"errors"
 	"fmt"
+	"math/big"
 	"reflect"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 )
 
 var errConvFail = errors.New("convert failed")
@@ -137,6 +142,9 @@ func IsEmpty(v interface{}) bool {
 	}
 
 	_, rv := Indirect(v)
+	if !rv.IsValid() {
+		return true
+	}
 	return rv.IsZero()
 }
 
@@ -154,29 +162,42 @@ func Field(v interface{}, field interface{}, def ...interface{}) interface{} {
 }
 
 // FieldE return the field value from map/struct, ignore the field type
+// the field can be a path of nested field, eg: "user.addresses[0].city", see PathE
 func FieldE(val interface{}, field interface{}) (interface{}, error) {
 	if val == nil {
 		return nil, errUnsupportedTypeNil
 	}
 
 	sf := String(field) // match with the String of field, so field can be any type
+	if v, ok := fieldValue(val, sf); ok {
+		return v, nil
+	}
+	// the key like "a.b" is matched first, then the path
+	if strings.ContainsAny(sf, ".[") {
+		return PathE(val, sf)
+	}
+
+	return nil, fmt.Errorf("%w(%s)", errFieldNotFound, sf)
+}
+
+// returns the value of map key or struct field
+func fieldValue(val interface{}, sf string) (interface{}, bool) {
 	_, rv := Indirect(val)
 
 	switch rv.Kind() {
 	case reflect.Map: // key of map
 		for _, key := range rv.MapKeys() {
 			if String(key.Interface()) == sf {
-				return rv.MapIndex(key).Interface(), nil
+				return rv.MapIndex(key).Interface(), true
 			}
 		}
-	case reflect.Struct: // field of struct
-		vv := rv.FieldByName(sf)
-		if vv.IsValid() {
-			return vv.Interface(), nil
+	case reflect.Struct: // field of struct, by the field name, the name of StructTag, or FieldMatch
+		if vv := fieldByName(rv, sf, false); vv.IsValid() && vv.CanInterface() {
+			return vv.Interface(), true
 		}
 	}
 
-	return nil, fmt.Errorf("%w(%s)", errFieldNotFound, sf)
+	return nil, false
 }
 
 // Typeof returns a string containing the name of the type of `val`.
@@ -184,10 +205,257 @@ func Typeof(val interface{}) string {
 	return fmt.Sprintf("%T", val)
 }
 
+// StructTag the tag key of struct field, used by StringMapE, SliceE, FieldE, KeysE and ColumnsE, default "json"
+// * `json:"name"` renames the field
+// * `json:"-"` omits the field
+// * `json:",omitempty"` omits the field with empty value, only for StringMapE
+// * `json:",string"` converts the bool, number and string value to string, only for StringMapE
+// set to "" to ignore the tag
+var StructTag = "json"
+
+// the options of struct field tag
+type fieldTag struct {
+	name      string
+	omit      bool
+	omitEmpty bool
+	asString  bool
+}
+
+// parse the tag of struct field by StructTag
+func parseFieldTag(f reflect.StructField) (tag fieldTag) {
+	if StructTag == "" {
+		return
+	}
+
+	v, ok := f.Tag.Lookup(StructTag)
+	if !ok {
+		return
+	}
+	if v == "-" {
+		tag.omit = true
+		return
+	}
+
+	opts := strings.Split(v, ",")
+	tag.name = opts[0]
+	for _, opt := range opts[1:] {
+		switch opt {
+		case "omitempty":
+			tag.omitEmpty = true
+		case "string":
+			tag.asString = true
+		}
+	}
+	return
+}
+
+// returns the key of struct field, the name of tag or field
+func (tag fieldTag) key(f reflect.StructField) string {
+	if tag.name != "" {
+		return tag.name
+	}
+	return f.Name
+}
+
+// the embedded struct without tag name is flattened
+func (tag fieldTag) flatten(f reflect.StructField) bool {
+	return f.Anonymous && tag.name == "" && ptrType(f.Type).Kind() == reflect.Struct
+}
+
+// FieldMatchMode the mode of matching the struct field by name, used by FieldE, PathE and SetFieldE
+type FieldMatchMode int
+
+// the modes of matching struct field, the field name and the name of StructTag are always matched exactly first
+const (
+	FieldMatchFold  FieldMatchMode = 1 << iota // case-insensitive, eg: "username" matches UserName
+	FieldMatchSnake                            // ignore the case and underscore, eg: "user_name" matches UserName
+)
+
+// FieldMatch the mode of matching the struct field by name, default 0, only match exactly
+// combine the modes by |, eg: cvt.FieldMatchFold | cvt.FieldMatchSnake
+var FieldMatch FieldMatchMode
+
+// the index of struct fields by name, cached by the type and StructTag
+type structIndex struct {
+	names map[string][]int // the exported field name, nil for the ambiguous name, same as reflect.Type.FieldByName
+	tags  map[string][]int // the name of StructTag
+	fold  map[string][]int // the lower case name of tag or field, nil for the ambiguous name
+	snake map[string][]int // the lower case name of tag or field without underscore, nil for the ambiguous name
+}
+
+type structIndexKey struct {
+	typ reflect.Type
+	tag string
+}
+
+var structIndexCache sync.Map // map[structIndexKey]*structIndex
+
+// returns the cached index of struct fields
+func cachedStructIndex(t reflect.Type) *structIndex {
+	key := structIndexKey{t, StructTag}
+	if si, ok := structIndexCache.Load(key); ok {
+		return si.(*structIndex)
+	}
+	si, _ := structIndexCache.LoadOrStore(key, newStructIndex(t))
+	return si.(*structIndex)
+}
+
+// index the fields by breadth-first, the embedded field has a low priority
+func newStructIndex(t reflect.Type) *structIndex {
+	si := &structIndex{
+		names: make(map[string][]int),
+		tags:  make(map[string][]int),
+		fold:  make(map[string][]int),
+		snake: make(map[string][]int),
+	}
+	add := func(m map[string][]int, name string, index []int) {
+		if _, ok := m[name]; !ok && name != "" {
+			m[name] = index
+		}
+	}
+	// add the index of field to the names of one level, once for the same name of tag and field
+	addLevel := func(m map[string][][]int, index []int, name, fieldName string) {
+		m[name] = append(m[name], index)
+		if fieldName != name {
+			m[fieldName] = append(m[fieldName], index)
+		}
+	}
+	// merge the names of one level, the name matched by more than one field is ambiguous
+	merge := func(m map[string][]int, level map[string][][]int) {
+		for name, indexes := range level {
+			if _, ok := m[name]; ok {
+				continue
+			}
+			if len(indexes) > 1 {
+				m[name] = nil
+				continue
+			}
+			m[name] = indexes[0]
+		}
+	}
+
+	type embedded struct {
+		typ   reflect.Type
+		index []int
+		flat  bool // flattened by StructTag
+	}
+	visited := map[reflect.Type]bool{t: true}
+	level := []embedded{{t, nil, true}}
+	for len(level) > 0 {
+		var next []embedded
+		names := make(map[string][][]int)
+		fold := make(map[string][][]int)
+		snake := make(map[string][][]int)
+		for _, e := range level {
+			for j := 0; j < e.typ.NumField(); j++ {
+				f := e.typ.Field(j)
+				index := append(append([]int(nil), e.index...), j)
+				tag := parseFieldTag(f)
+
+				if f.PkgPath == "" {
+					names[f.Name] = append(names[f.Name], index)
+				}
+				if e.flat && !tag.omit && (f.PkgPath == "" || f.Anonymous) {
+					add(si.tags, tag.name, index)
+					name := tag.key(f)
+					addLevel(fold, index, strings.ToLower(name), strings.ToLower(f.Name))
+					addLevel(snake, index, snakeKey(name), snakeKey(f.Name))
+				}
+
+				if ft := ptrType(f.Type); f.Anonymous && ft.Kind() == reflect.Struct && !visited[ft] {
+					visited[ft] = true
+					next = append(next, embedded{ft, index, e.flat && tag.flatten(f)})
+				}
+			}
+		}
+		merge(si.names, names)
+		merge(si.fold, fold)
+		merge(si.snake, snake)
+		level = next
+	}
+
+	return si
+}
+
+// the name to match in FieldMatchSnake
+//
+//	"user_name", "userName", "UserName" => "username"
+func snakeKey(name string) string {
+	return strings.ToLower(strings.Replace(name, "_", "", -1))
+}
+
+// returns the index of field by name, matched by the field name, the name of StructTag, then FieldMatch
+func (si *structIndex) lookup(name string) []int {
+	if index := si.names[name]; index != nil {
+		return index
+	}
+	if index, ok := si.tags[name]; ok {
+		return index
+	}
+	if FieldMatch&FieldMatchFold != 0 {
+		if index := si.fold[strings.ToLower(name)]; index != nil {
+			return index
+		}
+	}
+	if FieldMatch&FieldMatchSnake != 0 {
+		if index := si.snake[snakeKey(name)]; index != nil {
+			return index
+		}
+	}
+	return nil
+}
+
+// returns the field of struct by name, see structIndex.lookup
+// the nil embedded pointer on the way is allocated if alloc is true, or returns the invalid value
+func fieldByName(rv reflect.Value, name string, alloc bool) reflect.Value {
+	index := cachedStructIndex(rv.Type()).lookup(name)
+	if index == nil {
+		return reflect.Value{}
+	}
+
+	for j, i := range index {
+		if j > 0 && rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				if !alloc || !rv.CanSet() {
+					return reflect.Value{}
+				}
+				rv.Set(reflect.New(rv.Type().Elem()))
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(i)
+	}
+	return rv
+}
+
+// the empty value of omitempty, same as encoding/json
+func isEmptyValue(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len() == 0
+	case reflect.Bool:
+		return !rv.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return rv.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return rv.IsNil()
+	}
+	return false
+}
+
 // return the values of struct fields, and deep find the embedded fields
 func deepStructValues(rv reflect.Value) (sl []interface{}) {
 	for j := 0; j < rv.NumField(); j++ {
-		if rv.Type().Field(j).Anonymous {
+		f := rv.Type().Field(j)
+		tag := parseFieldTag(f)
+		if tag.omit {
+			continue
+		}
+		if f.Anonymous && tag.name == "" {
 			sl = append(sl, deepStructValues(rv.Field(j))...)
 		} else if rv.Field(j).CanInterface() {
 			sl = append(sl, rv.Field(j).Interface())
@@ -197,8 +465,16 @@ func deepStructValues(rv reflect.Value) (sl []interface{}) {
 }
 
 // return the name of struct fields, and deep find the embedded fields
-func deepStructFields(rt reflect.Type) (sl []string) {
+func deepStructFields(rt reflect.Type) []string {
+	return embeddedStructFields(rt, make(map[reflect.Type]bool))
+}
+
+// returns the deepStructFields of rt, the visiting is the struct types in the chain of embedding,
+// the embedded cycle is skipped
+func embeddedStructFields(rt reflect.Type, visiting map[reflect.Type]bool) (sl []string) {
 	rt = ptrType(rt)
+	visiting[rt] = true
+	defer delete(visiting, rt)
 
 	type field struct {
 		level int8
@@ -220,14 +496,20 @@ func deepStructFields(rt reflect.Type) (sl []string) {
 	// sort by field definition order, include embed field
 	for j := 0; j < rt.NumField(); j++ {
 		f := rt.Field(j)
-		t := ptrType(f.Type)
+		tag := parseFieldTag(f)
+		if tag.omit {
+			continue
+		}
 		// embed struct, include pointer struct
-		if f.Anonymous && t.Kind() == reflect.Struct {
-			for _, v := range deepStructFields(t) {
+		if tag.flatten(f) {
+			if visiting[ptrType(f.Type)] {
+				continue
+			}
+			for _, v := range embeddedStructFields(f.Type, visiting) {
 				fn(v, 1)
 			}
 		} else { // single field, include pointer field
-			fn(f.Name, 0)
+			fn(tag.key(f), 0)
 		}
 	}
 
@@ -238,7 +520,11 @@ func deepStructFields(rt reflect.Type) (sl []string) {
 func sortedMapKeys(v reflect.Value) (s []reflect.Value) {
 	s = v.MapKeys()
 	sort.Slice(s, func(i, j int) bool {
-		return strings.Compare(String(s[i].Interface()), String(s[j].Interface())) < 0
+		if c := strings.Compare(String(s[i].Interface()), String(s[j].Interface())); c != 0 {
+			return c < 0
+		}
+		// same string of different types, eg: 1 and "1"
+		return Typeof(s[i].Interface()) < Typeof(s[j].Interface())
 	})
 	return
 }
@@ -261,6 +547,31 @@ func ptrValue(rv reflect.Value) reflect.Value {
 	return rv
 }
 
+// returns the value with base type for the scalar converters, same as Indirect,
+// and unwrap the driver.Valuer if its base type is not a scalar, such as sql.NullInt64,
+// the invalid sql.Null* returns nil value
+func indirectScalar(a interface{}) (interface{}, reflect.Value) {
+	val, rv := Indirect(a)
+	if vv, ok := a.(driver.Valuer); ok && val != nil && !isScalarKind(rv.Kind()) {
+		if v, err := vv.Value(); err == nil {
+			return Indirect(v)
+		}
+	}
+	return val, rv
+}
+
+// the kind can be converted by the scalar converters directly
+func isScalarKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	}
+	return false
+}
+
 // Indirect returns the value with base type
 func Indirect(a interface{}) (val interface{}, rv reflect.Value) {
 	if a == nil {
@@ -307,6 +618,10 @@ func Indirect(a interface{}) (val interface{}, rv reflect.Value) {
 		val = float32(rv.Float())
 	case reflect.Float64:
 		val = rv.Float()
+	case reflect.Complex64:
+		val = complex64(rv.Complex())
+	case reflect.Complex128:
+		val = rv.Complex()
 	case reflect.String:
 		val = rv.String()
 	case reflect.Slice:
@@ -319,6 +634,184 @@ func Indirect(a interface{}) (val interface{}, rv reflect.Value) {
 	return
 }
 
+var (
+	typeTime            = reflect.TypeOf(time.Time{})
+	typeDuration        = reflect.TypeOf(time.Duration(0))
+	typeDecimal         = reflect.TypeOf(Decimal{})
+	typeBigInt          = reflect.TypeOf((*big.Int)(nil))
+	typeBigFloat        = reflect.TypeOf((*big.Float)(nil))
+	typeBigRat          = reflect.TypeOf((*big.Rat)(nil))
+	typeTextUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// convert any value to the value of type t, by the converter of t's kind
+// * the alias type is supported, eg: type MyInt int
+// * slice and map are converted element by element
+// * pointer is allocated, nil for the null value
+// * struct is decoded from map, struct or JSON object, same as DecodeE
+// * the type implements encoding.TextUnmarshaler is unmarshalled from the string
+func convValue(val interface{}, t reflect.Type) (reflect.Value, error) {
+	return defaultConverter().convValue(val, t)
+}
+
+// convert any value to the value of type t, the nested slice, map and struct are converted by the options of c
+func (c Converter) convValue(val interface{}, t reflect.Type) (reflect.Value, error) {
+	if val == nil {
+		if t.Kind() == reflect.Interface || t.Kind() == reflect.Ptr {
+			return reflect.Zero(t), nil
+		}
+	} else if reflect.TypeOf(val).AssignableTo(t) {
+		return reflect.ValueOf(val), nil
+	}
+
+	var v interface{}
+	var err error
+	switch t {
+	case typeTime:
+		v, err = TimeE(val)
+		return reflect.ValueOf(v), err
+	case typeDuration:
+		v, err = DurationE(val)
+		return reflect.ValueOf(v), err
+	case typeDecimal:
+		v, err = DecimalE(val)
+		return reflect.ValueOf(v), err
+	case typeBigInt:
+		v, err = BigIntE(val)
+		return reflect.ValueOf(v), err
+	case typeBigFloat:
+		v, err = BigFloatE(val)
+		return reflect.ValueOf(v), err
+	case typeBigRat:
+		v, err = BigRatE(val)
+		return reflect.ValueOf(v), err
+	}
+
+	switch t.Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
+		if reflect.PtrTo(t).Implements(typeTextUnmarshaler) {
+			p := reflect.New(t)
+			if isNull(val) {
+				return p.Elem(), nil
+			}
+			err = UnmarshalTextE(val, p.Interface().(encoding.TextUnmarshaler))
+			return p.Elem(), err
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		v, err = BoolE(val)
+	case reflect.Int:
+		v, err = IntE(val)
+	case reflect.Int8:
+		v, err = Int8E(val)
+	case reflect.Int16:
+		v, err = Int16E(val)
+	case reflect.Int32:
+		v, err = Int32E(val)
+	case reflect.Int64:
+		v, err = Int64E(val)
+	case reflect.Uint:
+		v, err = UintE(val)
+	case reflect.Uint8:
+		v, err = Uint8E(val)
+	case reflect.Uint16:
+		v, err = Uint16E(val)
+	case reflect.Uint32:
+		v, err = Uint32E(val)
+	case reflect.Uint64:
+		v, err = Uint64E(val)
+	case reflect.Float32:
+		v, err = Float32E(val)
+	case reflect.Float64:
+		v, err = Float64E(val)
+	case reflect.Complex64:
+		v, err = Complex64E(val)
+	case reflect.Complex128:
+		v, err = Complex128E(val)
+	case reflect.String:
+		v, err = c.FloatFormat.StringE(val)
+	case reflect.Slice:
+		return c.convSliceValue(val, t)
+	case reflect.Array:
+		return c.convArrayValue(val, t)
+	case reflect.Map:
+		return c.convMap(val, t)
+	case reflect.Struct:
+		rv := reflect.New(t).Elem()
+		if isNull(val) {
+			return rv, nil
+		}
+		return rv, c.decodeStruct(val, rv)
+	case reflect.Ptr:
+		if isNull(val) {
+			return reflect.Zero(t), nil
+		}
+		e, err := c.convValue(val, t.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		p := reflect.New(t.Elem())
+		p.Elem().Set(e)
+		return p, nil
+	default:
+		return reflect.Value{}, newErr(val, t.String())
+	}
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	return reflect.ValueOf(v).Convert(t), nil
+}
+
+// convert any value to the slice of type t, element by element
+func (c Converter) convSliceValue(val interface{}, t reflect.Type) (reflect.Value, error) {
+	// []byte from string
+	if t.Elem().Kind() == reflect.Uint8 {
+		if _, rv := Indirect(val); rv.Kind() == reflect.String {
+			return reflect.ValueOf([]byte(rv.String())).Convert(t), nil
+		}
+	}
+
+	var sl reflect.Value
+	err := c.convSlice(val, t.Elem().Kind(), func(n int) { sl = reflect.MakeSlice(t, n, n) }, func(j int, v interface{}) error {
+		e, err := c.convValue(v, t.Elem())
+		// keep the partial result of nested slice and map in the mode of collecting errors
+		if e.IsValid() {
+			sl.Index(j).Set(e)
+		}
+		return err
+	})
+	if _, ok := err.(ElementErrors); err != nil && !ok {
+		return reflect.Value{}, err
+	}
+	if !sl.IsValid() {
+		sl = reflect.Zero(t)
+	}
+
+	return sl, err
+}
+
+// convert any value to the array of type t, element by element, the length must be equal
+func (c Converter) convArrayValue(val interface{}, t reflect.Type) (reflect.Value, error) {
+	arr := reflect.New(t).Elem()
+	if isNull(val) {
+		return arr, nil
+	}
+
+	sl, err := c.convSliceValue(val, reflect.SliceOf(t.Elem()))
+	if _, ok := err.(ElementErrors); err != nil && !ok {
+		return reflect.Value{}, err
+	}
+	if sl.Len() != t.Len() {
+		return reflect.Value{}, fmt.Errorf("%w, length %d does not match %d", newErr(val, t.String()), sl.Len(), t.Len())
+	}
+	reflect.Copy(arr, sl)
+
+	return arr, err
+}
+
 func newErr(val interface{}, t string) error {
 	return fmt.Errorf("unable to convert %#v of type %T to %s", val, val, t)
 }
@@ -16,23 +16,25 @@ import (
 
 // indirect type
 type (
-	AliasTypeBool      bool
-	AliasTypeInt       int
-	PointerTypeInt     *AliasTypeInt
-	AliasTypeInt8      int8
-	AliasTypeInt16     int16
-	AliasTypeInt32     int32
-	AliasTypeInt64     int64
-	AliasTypeUint      uint
-	AliasTypeUint8     uint8
-	AliasTypeUint16    uint16
-	AliasTypeUint32    uint32
-	AliasTypeUint64    uint64
-	AliasTypeFloat32   float32
-	AliasTypeFloat64   float64
-	AliasTypeString    string
-	AliasTypeBytes     []byte
-	AliasTypeInterface interface{}
+	AliasTypeBool       bool
+	AliasTypeInt        int
+	PointerTypeInt      *AliasTypeInt
+	AliasTypeInt8       int8
+	AliasTypeInt16      int16
+	AliasTypeInt32      int32
+	AliasTypeInt64      int64
+	AliasTypeUint       uint
+	AliasTypeUint8      uint8
+	AliasTypeUint16     uint16
+	AliasTypeUint32     uint32
+	AliasTypeUint64     uint64
+	AliasTypeFloat32    float32
+	AliasTypeFloat64    float64
+	AliasTypeComplex64  complex64
+	AliasTypeComplex128 complex128
+	AliasTypeString     string
+	AliasTypeBytes      []byte
+	AliasTypeInterface  interface{}
 )
 
 var (
@@ -77,6 +79,28 @@ func (TestMarshalJSON) MarshalJSON() ([]byte, error) {
 	return []byte("MarshalJSON"), nil
 }
 
+type TestMarshalText struct{}
+
+func (TestMarshalText) MarshalText() ([]byte, error) {
+	return []byte("MarshalText"), nil
+}
+
+type TestMarshalTextJSON struct{}
+
+func (TestMarshalTextJSON) MarshalText() ([]byte, error) {
+	return []byte("MarshalText"), nil
+}
+
+func (TestMarshalTextJSON) MarshalJSON() ([]byte, error) {
+	return []byte(`"MarshalText"`), nil
+}
+
+type TestMarshalTextErr struct{}
+
+func (TestMarshalTextErr) MarshalText() ([]byte, error) {
+	return nil, errors.New("MarshalText")
+}
+
 type TestStructA struct {
 	A1 int
 	TestStructB
@@ -106,6 +130,20 @@ type TestStructE struct {
 	DD *TestStructD
 }
 
+type TestStructTag struct {
+	ID       int    `json:"id" cvt:"uid"`
+	Name     string `json:"name,omitempty"`
+	Password string `json:"-"`
+	Age      int    `json:"age,string"`
+	Note     string
+	TestStructTagEmbed
+	Meta TestStructD `json:"meta"`
+}
+
+type TestStructTagEmbed struct {
+	Level int `json:"level"`
+}
+
 type TestTimeStringer struct {
 	time time.Time
 }
@@ -161,6 +199,155 @@ func TestField_BaseLine(t *testing.T) {
 	}
 }
 
+func TestFieldE_StructTag(t *testing.T) {
+	defer func(tag string) {
+		cvt.StructTag = tag
+	}(cvt.StructTag)
+
+	input := TestStructTag{ID: 1, TestStructTagEmbed: TestStructTagEmbed{Level: 3}}
+
+	cvt.StructTag = "cvt"
+	assertEqual(t, 1, cvt.Field(input, "uid"))
+	assertEqual(t, nil, cvt.Field(input, "id"))
+	assertEqual(t, 3, cvt.Field(input, "Level"))
+
+	cvt.StructTag = ""
+	assertEqual(t, nil, cvt.Field(input, "id"))
+	assertEqual(t, 1, cvt.Field(input, "ID"))
+}
+
+type TestStructMatch struct {
+	UserName  string `json:"user_name"`
+	NickName  string
+	UserEmail string `json:"-"`
+	userID    int
+	*TestStructMatchEmbed
+	TestStructMatchA
+	TestStructMatchB
+}
+
+type TestStructMatchEmbed struct {
+	Level    int `json:"level"`
+	NickName string
+}
+
+type TestStructMatchA struct {
+	Same string
+	Deep string
+}
+
+type TestStructMatchB struct {
+	Same string
+}
+
+type TestStructMatchAmbiguous struct {
+	name      string
+	FullName  string `json:"name"`
+	UserName  string
+	Username  string
+	User_Name string
+}
+
+func TestFieldE_Match(t *testing.T) {
+	defer func(m cvt.FieldMatchMode) {
+		cvt.FieldMatch = m
+	}(cvt.FieldMatch)
+
+	input := TestStructMatch{
+		UserName:             "bob",
+		NickName:             "b",
+		UserEmail:            "e",
+		TestStructMatchEmbed: &TestStructMatchEmbed{Level: 3, NickName: "x"},
+		TestStructMatchA:     TestStructMatchA{"a", "d"},
+		TestStructMatchB:     TestStructMatchB{"b"},
+	}
+
+	tests := []struct {
+		mode   cvt.FieldMatchMode
+		field  string
+		expect interface{}
+		isErr  bool
+	}{
+		{0, "UserName", "bob", false},
+		{0, "user_name", "bob", false},
+		{0, "NickName", "b", false},
+		{0, "UserEmail", "e", false},
+		{0, "level", 3, false},
+		{0, "Level", 3, false},
+		{0, "Deep", "d", false},
+		{0, "username", nil, true},
+		{0, "nickname", nil, true},
+		{0, "Same", nil, true},
+		{0, "userID", nil, true},
+		{cvt.FieldMatchFold, "username", "bob", false},
+		{cvt.FieldMatchFold, "USER_NAME", "bob", false},
+		{cvt.FieldMatchFold, "nickname", "b", false},
+		{cvt.FieldMatchFold, "LEVEL", 3, false},
+		{cvt.FieldMatchFold, "deep", "d", false},
+		{cvt.FieldMatchFold, "userName", "bob", false},
+		{cvt.FieldMatchFold, "user-name", nil, true},
+		{cvt.FieldMatchFold, "useremail", nil, true},
+		{cvt.FieldMatchFold, "userid", nil, true},
+		{cvt.FieldMatchSnake, "userName", "bob", false},
+		{cvt.FieldMatchSnake, "username", "bob", false},
+		{cvt.FieldMatchSnake, "nick_name", "b", false},
+		{cvt.FieldMatchSnake, "Nick_Name", "b", false},
+		{cvt.FieldMatchSnake, "user_email", nil, true},
+		{cvt.FieldMatchFold | cvt.FieldMatchSnake, "nick_name", "b", false},
+		{cvt.FieldMatchFold | cvt.FieldMatchSnake, "USERNAME", "bob", false},
+
+		// ambiguous at the same depth
+		{cvt.FieldMatchFold, "same", nil, true},
+		{cvt.FieldMatchSnake, "SAME", nil, true},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, mode[%d], field[%s], expect[%+v], isErr[%v]", i, tt.mode, tt.field, tt.expect, tt.isErr)
+
+		cvt.FieldMatch = tt.mode
+		v, err := cvt.FieldE(input, tt.field)
+		if tt.isErr {
+			assertError(t, err, "[HasErr] "+msg)
+			continue
+		}
+
+		assertNoError(t, err, msg)
+		assertEqual(t, tt.expect, v, "[WithE] "+msg)
+	}
+
+	// the nil embedded pointer
+	cvt.FieldMatch = cvt.FieldMatchFold
+	_, err := cvt.FieldE(TestStructMatch{}, "level")
+	assertError(t, err)
+
+	var v TestStructMatch
+	assertNoError(t, cvt.SetFieldE(&v, "LEVEL", "5"))
+	assertEqual(t, 5, v.Level)
+	assertEqual(t, "x", cvt.Path(map[string]interface{}{"u": input}, "u.TestStructMatchEmbed.nickname"))
+
+	// the unexported field is skipped, and the ambiguous name is not found, same as encoding/json
+	ambiguous := TestStructMatchAmbiguous{name: "n", FullName: "f", UserName: "a", Username: "b", User_Name: "c"}
+	cvt.FieldMatch = cvt.FieldMatchFold | cvt.FieldMatchSnake
+	assertEqual(t, "f", cvt.Field(ambiguous, "name"))
+	assertEqual(t, "a", cvt.Field(ambiguous, "UserName"))
+	assertEqual(t, "b", cvt.Field(ambiguous, "Username"))
+	assertEqual(t, "c", cvt.Field(ambiguous, "user_name"))
+	_, err = cvt.FieldE(ambiguous, "USERNAME")
+	assertError(t, err)
+	cvt.FieldMatch = cvt.FieldMatchSnake
+	_, err = cvt.FieldE(ambiguous, "User_name")
+	assertError(t, err)
+	assertError(t, cvt.SetFieldE(&ambiguous, "username", "x"))
+	assertEqual(t, "a", ambiguous.UserName)
+}
+
+func BenchmarkFieldE(b *testing.B) {
+	input := TestStructMatch{UserName: "bob", TestStructMatchEmbed: &TestStructMatchEmbed{Level: 3}}
+	for i := 0; i < b.N; i++ {
+		_, _ = cvt.FieldE(input, "level")
+	}
+}
+
 func TestFieldE(t *testing.T) {
 	tests := []struct {
 		input  interface{}
@@ -204,8 +391,16 @@ func TestFieldE(t *testing.T) {
 		{map[int]interface{}{123: "112233"}, 123, "112233", false},
 		{map[string]interface{}{"123": "112233"}, 123, "112233", false},
 		{map[string]interface{}{"c": "ccc"}, TestStructC{C1: "c"}, "ccc", false},
+		{TestStructTag{ID: 1}, "id", 1, false},
+		{&TestStructTag{ID: 1}, "ID", 1, false},
+		{TestStructTag{Password: "x"}, "Password", "x", false},
+		{TestStructTag{TestStructTagEmbed: TestStructTagEmbed{Level: 3}}, "level", 3, false},
+		{TestStructTag{Meta: TestStructD{D1: 1}}, "meta", TestStructD{D1: 1}, false},
 
 		// errors
+		{TestStructTag{}, "Password2", nil, true},
+		{TestStructTag{}, "-", nil, true},
+		{TestStructTag{}, "uid", nil, true},
 		{TestStructE{D1: 1, DD: &TestStructD{D1: 2}}, "", nil, true},
 		{TestStructE{D1: 1, DD: &TestStructD{D1: 2}}, "Age", nil, true},
 		{int(123), "Name", nil, true},
@@ -249,6 +444,8 @@ func TestIndirect(t *testing.T) {
 		{&pointerIntNil, nil},
 		{pointerRunes, []rune("中国")},
 		{&pointerRunes, []rune("中国")},
+		{AliasTypeComplex64(3 + 4i), complex64(3 + 4i)},
+		{AliasTypeComplex128(3 + 4i), complex128(3 + 4i)},
 	}
 
 	for i, tt := range tests {
@@ -0,0 +1,298 @@
+package cvt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// RoundingMode the mode of rounding a Decimal
+type RoundingMode int
+
+// the rounding modes, the "half" modes only differ on the tie
+const (
+	RoundHalfUp   RoundingMode = iota // round to nearest, ties away from zero
+	RoundHalfEven                     // round to nearest, ties to even, aka banker's rounding
+	RoundHalfDown                     // round to nearest, ties toward zero
+	RoundUp                           // round away from zero
+	RoundDown                         // round toward zero, aka truncate
+	RoundCeiling                      // round toward positive infinity
+	RoundFloor                        // round toward negative infinity
+)
+
+var errInexact = errors.New("inexact conversion")
+
+// the max absolute scale of parsed Decimal, the larger scale is rejected,
+// because the arithmetic builds the power of 10 by the scale, eg: "1e-900000000"
+const maxDecimalScale = 10000
+
+// Decimal the exact decimal number, represented by coefficient * 10^(-scale)
+// the zero value is 0, and a Decimal is immutable
+type Decimal struct {
+	coef  *big.Int
+	scale int32
+}
+
+// NewDecimal returns the Decimal of coef * 10^(-scale)
+//
+//	NewDecimal(1234, 2) => 12.34
+func NewDecimal(coef int64, scale int32) Decimal {
+	return Decimal{coef: big.NewInt(coef), scale: scale}
+}
+
+// DecimalE convert an interface to a Decimal type
+// * string and json.Number are parsed exactly, eg: "12.34", "-1.5e3"
+// * float is converted by the shortest representation, eg: 0.1 => "0.1"
+// * the scale of string is kept, eg: "1.50" has scale 2
+// * the scale out of ±10000 is rejected, eg: "1e-900000000"
+// * NaN and ±Inf are handled by NonFinite, they are errors if kept
+func DecimalE(val interface{}) (Decimal, error) {
+	v, e := convDecimal(val)
+	if e := catch("cvt.Decimal", val, e); e != nil {
+		return Decimal{}, e
+	}
+
+	return v, nil
+}
+
+// convert any value to Decimal
+func convDecimal(val interface{}) (Decimal, error) {
+	// direct type(for improve performance)
+	switch vv := val.(type) {
+	case Decimal:
+		return vv, nil
+	case *Decimal:
+		if vv != nil {
+			return *vv, nil
+		}
+	case string:
+		return str2decimal(vv)
+	case json.Number:
+		return str2decimal(vv.String())
+	case *big.Int:
+		if vv != nil {
+			return Decimal{coef: new(big.Int).Set(vv)}, nil
+		}
+	case *big.Float:
+		if vv != nil && vv.IsInf() {
+			return Decimal{}, nonFiniteErr()
+		}
+		if vv != nil {
+			// reject the huge exponent before formatting, 2^(maxDecimalScale*10/3) > 10^maxDecimalScale
+			if exp := vv.MantExp(nil); exp > maxDecimalScale*10/3 || exp < -maxDecimalScale*10/3 {
+				return Decimal{}, fmt.Errorf("%w, the exponent is out of range", errConvFail)
+			}
+			return str2decimal(vv.Text('g', -1))
+		}
+		return Decimal{}, errConvFail
+	}
+
+	// indirect type
+	v, rv := indirectScalar(val)
+	switch vv := v.(type) {
+	case nil:
+		return Decimal{}, nil
+	case bool:
+		if vv {
+			return NewDecimal(1, 0), nil
+		}
+		return Decimal{}, nil
+	case string:
+		return str2decimal(vv)
+	case []byte:
+		return str2decimal(string(vv))
+	case int, int8, int16, int32, int64:
+		return NewDecimal(rv.Int(), 0), nil
+	case uint, uint8, uint16, uint32, uint64, uintptr:
+		return Decimal{coef: new(big.Int).SetUint64(rv.Uint())}, nil
+	case float32:
+		return float2decimal(float64(vv), 32)
+	case float64:
+		return float2decimal(vv, 64)
+	case big.Int:
+		return Decimal{coef: new(big.Int).Set(&vv)}, nil
+	}
+
+	return Decimal{}, errConvFail
+}
+
+// convert float to Decimal, by the shortest representation
+func float2decimal(f float64, bitSize int) (Decimal, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Decimal{}, nonFiniteErr()
+	}
+	return str2decimal(strconv.FormatFloat(f, 'g', -1, bitSize))
+}
+
+// parse a decimal string, like "12.34", "-0.5", "+1.5e-3", ".5"
+func str2decimal(s string) (d Decimal, err error) {
+	errInvalid := fmt.Errorf("unable to parse decimal: %s", s)
+
+	// exponent
+	var exp int64
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		if exp, err = strconv.ParseInt(s[i+1:], 10, 32); err != nil {
+			return d, errInvalid
+		}
+		s = s[:i]
+	}
+
+	var neg bool
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+
+	var scale int64
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		scale = int64(len(s) - i - 1)
+		s = s[:i] + s[i+1:]
+	}
+	if s == "" || strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' }) >= 0 {
+		return d, errInvalid
+	}
+
+	scale -= exp
+	if scale > maxDecimalScale || scale < -maxDecimalScale {
+		return d, fmt.Errorf("%w, the scale %d is out of range ±%d", errInvalid, scale, maxDecimalScale)
+	}
+
+	coef, _ := new(big.Int).SetString(s, 10)
+	if neg {
+		coef.Neg(coef)
+	}
+	return Decimal{coef: coef, scale: int32(scale)}, nil
+}
+
+func (d Decimal) int() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+// Coefficient returns a copy of the coefficient
+func (d Decimal) Coefficient() *big.Int {
+	return new(big.Int).Set(d.int())
+}
+
+// Scale returns the number of digits after the decimal point
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// Sign returns -1, 0 or +1
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+// Cmp compares d and x, returns -1 if d < x, 0 if d == x, +1 if d > x
+func (d Decimal) Cmp(x Decimal) int {
+	scale := d.scale
+	if x.scale > scale {
+		scale = x.scale
+	}
+	return d.rescale(scale).Cmp(x.rescale(scale))
+}
+
+// returns the coefficient in the larger scale
+func (d Decimal) rescale(scale int32) *big.Int {
+	if scale <= d.scale {
+		return d.int()
+	}
+	return new(big.Int).Mul(d.int(), pow10(int64(scale)-int64(d.scale)))
+}
+
+// String returns the exact decimal string, keep the digits of scale
+//
+//	NewDecimal(150, 2) => "1.50"
+//	NewDecimal(15, -2) => "1500"
+func (d Decimal) String() string {
+	if d.scale <= 0 {
+		return d.rescale(0).String()
+	}
+
+	s := new(big.Int).Abs(d.int()).String()
+	if pad := int(d.scale) - len(s) + 1; pad > 0 {
+		s = strings.Repeat("0", pad) + s
+	}
+	s = s[:len(s)-int(d.scale)] + "." + s[len(s)-int(d.scale):]
+	if d.Sign() < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+// Float64 returns the nearest float64 value
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// Round returns the Decimal rounded to the scale, by the rounding mode
+//
+//	DecimalE("2.345").Round(2, RoundHalfUp) => 2.35
+//	DecimalE("2.345").Round(2, RoundHalfEven) => 2.34
+func (d Decimal) Round(scale int32, mode RoundingMode) Decimal {
+	if scale >= d.scale {
+		return Decimal{coef: d.rescale(scale), scale: scale}
+	}
+
+	pow := pow10(int64(d.scale) - int64(scale))
+	q, r := new(big.Int).QuoRem(d.int(), pow, new(big.Int))
+	if r.Sign() == 0 {
+		return Decimal{coef: q, scale: scale}
+	}
+
+	// compare the remainder with the half
+	r2 := new(big.Int).Abs(r)
+	half := r2.Mul(r2, big.NewInt(2)).Cmp(pow)
+
+	var away bool
+	switch mode {
+	case RoundHalfUp:
+		away = half >= 0
+	case RoundHalfDown:
+		away = half > 0
+	case RoundHalfEven:
+		away = half > 0 || (half == 0 && q.Bit(0) == 1)
+	case RoundUp:
+		away = true
+	case RoundDown:
+		away = false
+	case RoundCeiling:
+		away = d.Sign() > 0
+	case RoundFloor:
+		away = d.Sign() < 0
+	}
+	if away {
+		q.Add(q, big.NewInt(int64(d.Sign())))
+	}
+
+	return Decimal{coef: q, scale: scale}
+}
+
+// MinorUnits returns the integer of minor units exactly, such as cents with scale 2
+// returns an error if the value has more digits than the scale, or out of int64
+//
+//	DecimalE("12.34").MinorUnits(2) => 1234
+//	DecimalE("12.345").MinorUnits(2) => error
+func (d Decimal) MinorUnits(scale int32) (int64, error) {
+	r := d.Round(scale, RoundDown)
+	if r.Cmp(d) != 0 {
+		return 0, fmt.Errorf("%w, %s has more digits than scale %d", errInexact, d, scale)
+	}
+	if !r.int().IsInt64() {
+		return 0, fmt.Errorf("value %s out of range of int64", r.int())
+	}
+	return r.int().Int64(), nil
+}
+
+// returns 10^n
+func pow10(n int64) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(n), nil)
+}
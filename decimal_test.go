@@ -0,0 +1,203 @@
+package cvt_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/shockerli/cvt"
+)
+
+func TestDecimalE(t *testing.T) {
+	var dec = cvt.NewDecimal(1234, 2)
+
+	tests := []struct {
+		input  interface{}
+		expect string
+		scale  int32
+		isErr  bool
+	}{
+		{nil, "0", 0, false},
+		{true, "1", 0, false},
+		{false, "0", 0, false},
+		{12, "12", 0, false},
+		{int8(-12), "-12", 0, false},
+		{uint64(math.MaxUint64), "18446744073709551615", 0, false},
+		{0.1, "0.1", 1, false},
+		{0.30000000000000004, "0.30000000000000004", 17, false},
+		{float32(8.31), "8.31", 2, false},
+		{1e21, "1000000000000000000000", -21, false},
+		{-1.5e-7, "-0.00000015", 8, false},
+		{"12.34", "12.34", 2, false},
+		{"1.50", "1.50", 2, false},
+		{"-0.5", "-0.5", 1, false},
+		{"+.5", "0.5", 1, false},
+		{"5.", "5", 0, false},
+		{"1.5e3", "1500", -2, false},
+		{"1.5E-3", "0.0015", 4, false},
+		{"123456789012345678901234567890.123456789", "123456789012345678901234567890.123456789", 9, false},
+		{[]byte("12.34"), "12.34", 2, false},
+		{json.Number("12.34"), "12.34", 2, false},
+		{AliasTypeString("12.34"), "12.34", 2, false},
+		{&aliasTypeInt1, "1", 0, false},
+		{pointerIntNil, "0", 0, false},
+		{dec, "12.34", 2, false},
+		{&dec, "12.34", 2, false},
+		{big.NewInt(-12), "-12", 0, false},
+		{*big.NewInt(12), "12", 0, false},
+		{big.NewFloat(1.25), "1.25", 2, false},
+		{"1e-10000", "0." + strings.Repeat("0", 9999) + "1", 10000, false},
+		{"1e10000", "1" + strings.Repeat("0", 10000), -10000, false},
+
+		// errors
+		{"", "", 0, true},
+		{"-", "", 0, true},
+		{".", "", 0, true},
+		{"1.2.3", "", 0, true},
+		{"1e", "", 0, true},
+		{"1e99999999999", "", 0, true},
+		{".5e-2147483648", "", 0, true},
+		{"1e-900000000", "", 0, true},
+		{"1e900000000", "", 0, true},
+		{"1e-10001", "", 0, true},
+		{"0." + strings.Repeat("0", 10000) + "1", "", 0, true},
+		{new(big.Float).SetMantExp(big.NewFloat(1), -40000), "", 0, true},
+		{"12a", "", 0, true},
+		{"NaN", "", 0, true},
+		{"Inf", "", 0, true},
+		{math.NaN(), "", 0, true},
+		{math.Inf(-1), "", 0, true},
+		{new(big.Float).SetInf(false), "", 0, true},
+		{json.Number("hello"), "", 0, true},
+		{testing.T{}, "", 0, true},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, input[%+v], expect[%+v], isErr[%v]", i, tt.input, tt.expect, tt.isErr)
+
+		v, err := cvt.DecimalE(tt.input)
+		if tt.isErr {
+			assertError(t, err, "[HasErr] "+msg)
+			continue
+		}
+
+		assertNoError(t, err, "[NoErr] "+msg)
+		assertEqual(t, tt.expect, v.String(), "[WithE] "+msg)
+		assertEqual(t, tt.scale, v.Scale(), "[WithE] "+msg)
+	}
+}
+
+func TestDecimal_Round(t *testing.T) {
+	tests := []struct {
+		input  string
+		scale  int32
+		mode   cvt.RoundingMode
+		expect string
+	}{
+		{"2.345", 2, cvt.RoundHalfUp, "2.35"},
+		{"2.345", 2, cvt.RoundHalfEven, "2.34"},
+		{"2.355", 2, cvt.RoundHalfEven, "2.36"},
+		{"2.345", 2, cvt.RoundHalfDown, "2.34"},
+		{"2.3451", 2, cvt.RoundHalfDown, "2.35"},
+		{"2.341", 2, cvt.RoundUp, "2.35"},
+		{"2.349", 2, cvt.RoundDown, "2.34"},
+		{"2.341", 2, cvt.RoundCeiling, "2.35"},
+		{"2.349", 2, cvt.RoundFloor, "2.34"},
+		{"-2.345", 2, cvt.RoundHalfUp, "-2.35"},
+		{"-2.345", 2, cvt.RoundHalfEven, "-2.34"},
+		{"-2.345", 2, cvt.RoundHalfDown, "-2.34"},
+		{"-2.341", 2, cvt.RoundUp, "-2.35"},
+		{"-2.349", 2, cvt.RoundDown, "-2.34"},
+		{"-2.349", 2, cvt.RoundCeiling, "-2.34"},
+		{"-2.341", 2, cvt.RoundFloor, "-2.35"},
+		{"2.5", 0, cvt.RoundHalfEven, "2"},
+		{"3.5", 0, cvt.RoundHalfEven, "4"},
+		{"1250", -2, cvt.RoundHalfEven, "1200"},
+		{"1350", -2, cvt.RoundHalfEven, "1400"},
+		{"0.004", 2, cvt.RoundHalfUp, "0.00"},
+		{"2.30", 2, cvt.RoundHalfUp, "2.30"},
+		{"2.300", 2, cvt.RoundUp, "2.30"},
+		{"2.3", 4, cvt.RoundHalfUp, "2.3000"},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, input[%+v], scale[%d], mode[%d], expect[%+v]", i, tt.input, tt.scale, tt.mode, tt.expect)
+
+		d, err := cvt.DecimalE(tt.input)
+		assertNoError(t, err, msg)
+		v := d.Round(tt.scale, tt.mode)
+		assertEqual(t, tt.expect, v.String(), msg)
+	}
+}
+
+func TestDecimal_MinorUnits(t *testing.T) {
+	tests := []struct {
+		input  interface{}
+		scale  int32
+		expect int64
+		isErr  bool
+	}{
+		{"12.34", 2, 1234, false},
+		{"12.3", 2, 1230, false},
+		{"12.340", 2, 1234, false},
+		{"-0.01", 2, -1, false},
+		{12, 2, 1200, false},
+		{0.30000000000000004, 17, 30000000000000004, false},
+		{"1.5e3", 0, 1500, false},
+
+		// errors
+		{"12.345", 2, 0, true},
+		{0.30000000000000004, 2, 0, true},
+		{"92233720368547758.08", 2, 0, true},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, input[%+v], scale[%d], expect[%+v]", i, tt.input, tt.scale, tt.expect)
+
+		d, err := cvt.DecimalE(tt.input)
+		assertNoError(t, err, msg)
+		v, err := d.MinorUnits(tt.scale)
+		if tt.isErr {
+			assertError(t, err, "[HasErr] "+msg)
+			continue
+		}
+		assertNoError(t, err, "[NoErr] "+msg)
+		assertEqual(t, tt.expect, v, msg)
+
+		// back to string
+		back := cvt.NewDecimal(v, tt.scale)
+		assertEqual(t, 0, back.Cmp(d), msg)
+	}
+
+	assertEqual(t, "12.34", cvt.NewDecimal(1234, 2).String())
+	assertEqual(t, "0.05", cvt.NewDecimal(5, 2).String())
+	assertEqual(t, "-0.05", cvt.NewDecimal(-5, 2).String())
+	assertEqual(t, "500", cvt.NewDecimal(5, -2).String())
+}
+
+func TestDecimal_Methods(t *testing.T) {
+	var zero cvt.Decimal
+	assertEqual(t, "0", zero.String())
+	assertEqual(t, 0, zero.Sign())
+	assertEqual(t, int32(0), zero.Scale())
+	assertEqual(t, "0", zero.Coefficient().String())
+
+	d := cvt.NewDecimal(-1250, 3)
+	assertEqual(t, "-1.250", d.String())
+	assertEqual(t, -1, d.Sign())
+	assertEqual(t, "-1250", d.Coefficient().String())
+	assertEqual(t, -1.25, d.Float64())
+	assertEqual(t, 0, d.Cmp(cvt.NewDecimal(-125, 2)))
+	assertEqual(t, -1, d.Cmp(zero))
+	assertEqual(t, 1, zero.Cmp(d))
+
+	// immutable
+	d.Coefficient().SetInt64(1)
+	assertEqual(t, "-1.250", d.String())
+
+	// StringE by fmt.Stringer
+	assertEqual(t, "-1.250", cvt.String(d))
+}
@@ -0,0 +1,378 @@
+package cvt
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FieldError the error of converting a field, with the path of nested field
+//
+//	items[3].price: unable to convert "abc" of type string to float64
+type FieldError struct {
+	Path string // the path of field, eg: "items[3].price", "tags.name"
+	Err  error
+}
+
+func (e *FieldError) Error() string {
+	return e.Path + ": " + e.Err.Error()
+}
+
+// Unwrap returns the error of field
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// returns the path of the nested FieldError and ElementError, and the cause error
+func errorPath(err error) (string, error) {
+	switch e := err.(type) {
+	case *FieldError:
+		return "." + e.Path, e.Err
+	case *ElementError:
+		p, cause := errorPath(e.Err)
+		if e.Key != nil {
+			return "." + String(e.Key) + p, cause
+		}
+		return fmt.Sprintf("[%d]", e.Index) + p, cause
+	}
+	return "", err
+}
+
+// returns the FieldError of field name, join the path of nested error
+func newFieldError(name string, err error) error {
+	p, cause := errorPath(err)
+	return &FieldError{Path: name + p, Err: cause}
+}
+
+// DecodeE convert the src to the value that dst points to, the dst must be a non-nil pointer
+// * src can be map, struct, or JSON object of string and []byte
+// * the field is matched by the name of StructTag or field name, case-insensitive if no exact match
+// * the field is converted by the converters of its type, eg: IntE, TimeE, SliceStringE
+// * the embedded struct, nested struct, slice, array, map and pointer are supported, the pointer is allocated
+// * the array must have the same length as the src
+// * the field not in src is kept
+// * returns a *FieldError with the path of field, eg: "items[3].price"
+//
+//	var user User
+//	cvt.DecodeE(`{"id": "1", "name": "bob", "created": 1234567890}`, &user)
+func DecodeE(src interface{}, dst interface{}) error {
+	return defaultConverter().DecodeE(src, dst)
+}
+
+// DecodeE convert the src to the value that dst points to, by the options of c
+func (c Converter) DecodeE(src interface{}, dst interface{}) error {
+	rv := reflect.ValueOf(dst)
+	if dst == nil || rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("%w, the dst must be a non-nil pointer, got %T", errConvFail, dst)
+	}
+
+	var err error
+	if rv.Elem().Kind() == reflect.Struct && !isSpecialStruct(rv.Elem().Type()) {
+		// keep the fields not in src
+		err = c.decodeStruct(src, rv.Elem())
+	} else {
+		var v reflect.Value
+		if v, err = c.convValue(src, rv.Elem().Type()); err == nil {
+			rv.Elem().Set(v)
+		}
+	}
+	if err == nil {
+		return nil
+	}
+
+	if p, cause := errorPath(err); p != "" {
+		return &FieldError{Path: strings.TrimPrefix(p, "."), Err: cause}
+	}
+	return err
+}
+
+// the struct is converted by the special converter
+func isSpecialStruct(t reflect.Type) bool {
+	return t == typeTime || t == typeDecimal || reflect.PtrTo(t).Implements(typeTextUnmarshaler)
+}
+
+// decode the map, struct or JSON object to the settable struct value rv
+func (c Converter) decodeStruct(val interface{}, rv reflect.Value) error {
+	keys, values, err := mapEntries(val, c.FloatFormat)
+	if e := catch(rv.Type().String(), val, err); e != nil {
+		return e
+	}
+
+	src := make(map[string]interface{}, len(keys))
+	for j, k := range keys {
+		src[c.FloatFormat.String(k)] = values[j]
+	}
+	_, err = c.decodeFields(src, rv, map[reflect.Type]bool{rv.Type(): true})
+	return err
+}
+
+// decode the fields of struct rv from src, returns whether any field is matched
+// the visiting is the struct types in the chain of embedding, the embedded cycle is skipped
+func (c Converter) decodeFields(src map[string]interface{}, rv reflect.Value, visiting map[reflect.Type]bool) (matched bool, err error) {
+	for j := 0; j < rv.NumField(); j++ {
+		f := rv.Type().Field(j)
+		tag := parseFieldTag(f)
+		if tag.omit || (f.PkgPath != "" && !f.Anonymous) {
+			continue
+		}
+
+		field := rv.Field(j)
+		if tag.flatten(f) {
+			et := ptrType(f.Type)
+			if visiting[et] {
+				continue
+			}
+			visiting[et] = true
+			ok, err := c.decodeEmbedded(src, f, field, visiting)
+			delete(visiting, et)
+			if err != nil {
+				return matched, err
+			}
+			matched = matched || ok
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+
+		name := tag.key(f)
+		v, ok := lookupKey(src, name)
+		if !ok {
+			continue
+		}
+		matched = true
+
+		fv, err := c.convValue(v, f.Type)
+		if err != nil {
+			return matched, newFieldError(name, err)
+		}
+		field.Set(fv)
+	}
+
+	return matched, nil
+}
+
+// decode the embedded struct, the pointer is allocated if any field is matched
+func (c Converter) decodeEmbedded(src map[string]interface{}, f reflect.StructField, field reflect.Value, visiting map[reflect.Type]bool) (bool, error) {
+	if f.Type.Kind() != reflect.Ptr {
+		return c.decodeFields(src, field, visiting)
+	}
+	if f.PkgPath != "" {
+		return false, nil
+	}
+
+	sub := field
+	if field.IsNil() {
+		sub = reflect.New(f.Type.Elem())
+	}
+	ok, err := c.decodeFields(src, sub.Elem(), visiting)
+	if ok && err == nil {
+		field.Set(sub)
+	}
+	return ok, err
+}
+
+// returns the value of key, case-insensitive if no exact match, the smallest key wins if many are matched
+func lookupKey(m map[string]interface{}, key string) (v interface{}, ok bool) {
+	if v, ok = m[key]; ok {
+		return
+	}
+
+	var found string
+	for k := range m {
+		if strings.EqualFold(k, key) && (!ok || k < found) {
+			found, ok = k, true
+		}
+	}
+	return m[found], ok
+}
+
+// CopyError the error of CopyE, the other fields are copied still
+type CopyError struct {
+	Unmapped []string      // the fields of dst not found in src
+	Errors   []*FieldError // the fields failed to convert
+}
+
+func (e *CopyError) Error() string {
+	var msg []string
+	if len(e.Unmapped) > 0 {
+		msg = append(msg, "unmapped fields: "+strings.Join(e.Unmapped, ", "))
+	}
+	for _, fe := range e.Errors {
+		msg = append(msg, fe.Error())
+	}
+	return strings.Join(msg, "; ")
+}
+
+// CopyE copy the fields of struct src to struct dst, the dst must be a non-nil pointer of struct
+// * the field is matched by the mapping, the name of StructTag or field name, case-insensitive if no exact match
+// * the mapping is the dst field name to src field name, map to "-" to skip the dst field
+// * the embedded field has a low priority, same as KeysE
+// * the field is converted by the converters of its type, eg: int64 <=> string, time.Time <=> int64, *string <=> string
+// * returns a *CopyError with the unmapped and unconvertible fields
+//
+//	cvt.CopyE(&dto, model)
+//	cvt.CopyE(&dto, model, map[string]string{"UserID": "ID", "Password": "-"})
+func CopyE(dst, src interface{}, mapping ...map[string]string) error {
+	dv := reflect.ValueOf(dst)
+	if dst == nil || dv.Kind() != reflect.Ptr || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w, the dst must be a non-nil pointer of struct, got %T", errConvFail, dst)
+	}
+	_, sv := Indirect(src)
+	if sv.Kind() != reflect.Struct {
+		return newErr(src, dv.Elem().Type().String())
+	}
+
+	// the src fields by name, and the fold case
+	srcFields := exportedStructFields(sv.Type())
+	srcNames := make(map[string]bool, len(srcFields))
+	for _, name := range srcFields {
+		srcNames[name] = true
+	}
+	findSrc := func(name string) (string, bool) {
+		if srcNames[name] {
+			return name, true
+		}
+		for _, s := range srcFields {
+			if strings.EqualFold(s, name) {
+				return s, true
+			}
+		}
+		return "", false
+	}
+
+	var ce CopyError
+	for _, name := range exportedStructFields(dv.Elem().Type()) {
+		from, ok := name, false
+		for _, m := range mapping {
+			if v, has := m[name]; has {
+				from, ok = v, srcNames[v]
+			}
+		}
+		if from == "-" {
+			continue
+		}
+		if !ok {
+			if from, ok = findSrc(from); !ok {
+				ce.Unmapped = append(ce.Unmapped, name)
+				continue
+			}
+		}
+
+		sf := fieldByKey(sv, from, false)
+		df := fieldByKey(dv.Elem(), name, true)
+		if !sf.IsValid() || !df.IsValid() || !sf.CanInterface() || !df.CanSet() {
+			ce.Unmapped = append(ce.Unmapped, name)
+			continue
+		}
+
+		fv, err := convValue(sf.Interface(), df.Type())
+		if err != nil {
+			ce.Errors = append(ce.Errors, newFieldError(name, err).(*FieldError))
+			continue
+		}
+		df.Set(fv)
+	}
+
+	if len(ce.Unmapped) > 0 || len(ce.Errors) > 0 {
+		return &ce
+	}
+	return nil
+}
+
+// returns the keys of deepStructFields, without the unexported fields
+func exportedStructFields(t reflect.Type) (sl []string) {
+	for _, name := range deepStructFields(t) {
+		if f, ok := structFieldByKey(t, name); ok && f.PkgPath == "" {
+			sl = append(sl, name)
+		}
+	}
+	return
+}
+
+// returns the struct field by the key of deepStructFields, the embedded field has a low priority
+func structFieldByKey(t reflect.Type, key string) (reflect.StructField, bool) {
+	return embeddedFieldByKey(ptrType(t), key, make(map[reflect.Type]bool))
+}
+
+// returns the structFieldByKey of t, the embedded cycle is skipped
+func embeddedFieldByKey(t reflect.Type, key string, visiting map[reflect.Type]bool) (reflect.StructField, bool) {
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	var embedded []reflect.Type
+	for j := 0; j < t.NumField(); j++ {
+		f := t.Field(j)
+		tag := parseFieldTag(f)
+		if tag.omit {
+			continue
+		}
+		if !tag.flatten(f) {
+			if tag.key(f) == key {
+				return f, true
+			}
+			continue
+		}
+		if et := ptrType(f.Type); !visiting[et] {
+			embedded = append(embedded, et)
+		}
+	}
+	for _, et := range embedded {
+		if f, ok := embeddedFieldByKey(et, key, visiting); ok {
+			return f, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+// returns the field by the key of deepStructFields, the embedded field has a low priority
+// the nil embedded pointer is allocated if alloc is true, or skipped
+func fieldByKey(rv reflect.Value, key string, alloc bool) reflect.Value {
+	return embeddedFieldValue(rv, key, alloc, make(map[reflect.Type]bool))
+}
+
+// returns the fieldByKey of rv, the embedded cycle is skipped
+func embeddedFieldValue(rv reflect.Value, key string, alloc bool, visiting map[reflect.Type]bool) reflect.Value {
+	visiting[rv.Type()] = true
+	defer delete(visiting, rv.Type())
+
+	var embedded []reflect.Value
+	for j := 0; j < rv.NumField(); j++ {
+		f := rv.Type().Field(j)
+		tag := parseFieldTag(f)
+		if tag.omit {
+			continue
+		}
+		if !tag.flatten(f) {
+			if tag.key(f) == key {
+				return rv.Field(j)
+			}
+			continue
+		}
+		if visiting[ptrType(f.Type)] {
+			continue
+		}
+
+		field := rv.Field(j)
+		if field.Kind() == reflect.Ptr && field.IsNil() {
+			if !alloc || !field.CanSet() {
+				continue
+			}
+			// allocate only if the key is found
+			sub := reflect.New(f.Type.Elem())
+			if !embeddedFieldValue(sub.Elem(), key, alloc, visiting).IsValid() {
+				continue
+			}
+			field.Set(sub)
+		}
+		embedded = append(embedded, ptrValue(field))
+	}
+	for _, vv := range embedded {
+		if field := embeddedFieldValue(vv, key, alloc, visiting); field.IsValid() {
+			return field
+		}
+	}
+
+	return reflect.Value{}
+}
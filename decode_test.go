@@ -0,0 +1,305 @@
+package cvt_test
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/shockerli/cvt"
+)
+
+type TestDecodeItem struct {
+	Name  string  `json:"name"`
+	Price float64 `json:"price"`
+}
+
+type TestDecodeBase struct {
+	ID      int64     `json:"id"`
+	Created time.Time `json:"created"`
+}
+
+type TestDecodeExtra struct {
+	Note string `json:"note"`
+}
+
+type TestDecodeOrder struct {
+	TestDecodeBase
+	*TestDecodeExtra
+	User     string                    `json:"user"`
+	Items    []TestDecodeItem          `json:"items"`
+	Tags     []string                  `json:"tags"`
+	Attrs    map[string]int            `json:"attrs"`
+	Coupon   *TestDecodeItem           `json:"coupon"`
+	Discount *float64                  `json:"discount"`
+	TTL      time.Duration             `json:"ttl"`
+	IP       net.IP                    `json:"ip"`
+	Amount   cvt.Decimal               `json:"amount"`
+	Big      *big.Int                  `json:"big"`
+	Index    map[string]TestDecodeItem `json:"index"`
+	Secret   string                    `json:"-"`
+	private  int
+}
+
+func TestDecodeE(t *testing.T) {
+	discount := 0.5
+	created := time.Unix(1234567890, 0)
+
+	tests := []struct {
+		input  interface{}
+		expect TestDecodeOrder
+		isErr  bool
+	}{
+		{map[string]interface{}{
+			"id":       "1",
+			"created":  1234567890,
+			"note":     "n",
+			"user":     123,
+			"items":    []interface{}{map[string]interface{}{"name": "a", "price": "1.5"}, TestDecodeItem{"b", 2}},
+			"tags":     "[\"x\", \"y\"]",
+			"attrs":    map[string]string{"a": "1"},
+			"coupon":   map[string]interface{}{"name": "c"},
+			"discount": "0.5",
+			"ttl":      "1h",
+			"ip":       "127.0.0.1",
+			"amount":   "12.30",
+			"big":      "12345678901234567890",
+			"index":    map[string]interface{}{"k": map[string]interface{}{"price": 3}},
+			"Secret":   "s",
+		}, TestDecodeOrder{
+			TestDecodeBase:  TestDecodeBase{ID: 1, Created: created},
+			TestDecodeExtra: &TestDecodeExtra{Note: "n"},
+			User:            "123",
+			Items:           []TestDecodeItem{{"a", 1.5}, {"b", 2}},
+			Tags:            []string{"x", "y"},
+			Attrs:           map[string]int{"a": 1},
+			Coupon:          &TestDecodeItem{Name: "c"},
+			Discount:        &discount,
+			TTL:             time.Hour,
+			IP:              net.ParseIP("127.0.0.1"),
+			Amount:          cvt.Decimal{},
+			Big:             cvt.BigInt("12345678901234567890"),
+			Index:           map[string]TestDecodeItem{"k": {Price: 3}},
+		}, false},
+		{`{"ID": 2, "USER": "bob", "coupon": null, "items": [{"NAME": "a"}]}`, TestDecodeOrder{
+			TestDecodeBase: TestDecodeBase{ID: 2},
+			User:           "bob",
+			Items:          []TestDecodeItem{{Name: "a"}},
+		}, false},
+		{[]byte(`{}`), TestDecodeOrder{}, false},
+		{&TestDecodeOrder{User: "x", Secret: "s"}, TestDecodeOrder{User: "x"}, false},
+		{struct {
+			ID   string
+			User int
+		}{"3", 4}, TestDecodeOrder{TestDecodeBase: TestDecodeBase{ID: 3}, User: "4"}, false},
+
+		// errors
+		{nil, TestDecodeOrder{}, true},
+		{123, TestDecodeOrder{}, true},
+		{"hello", TestDecodeOrder{}, true},
+		{map[string]interface{}{"id": "a"}, TestDecodeOrder{}, true},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, input[%+v], expect[%+v], isErr[%v]", i, tt.input, tt.expect, tt.isErr)
+
+		var v TestDecodeOrder
+		err := cvt.DecodeE(tt.input, &v)
+		if tt.isErr {
+			assertError(t, err, "[HasErr] "+msg)
+			continue
+		}
+
+		assertNoError(t, err, "[NoErr] "+msg)
+		if i == 0 {
+			// Decimal keeps the scale, compare by string
+			assertEqual(t, "12.30", v.Amount.String(), "[WithE] "+msg)
+			v.Amount = cvt.Decimal{}
+		}
+		assertEqual(t, tt.expect, v, "[WithE] "+msg)
+	}
+}
+
+func TestDecodeE_Path(t *testing.T) {
+	tests := []struct {
+		input interface{}
+		path  string
+	}{
+		{map[string]interface{}{"id": "a"}, "id"},
+		{map[string]interface{}{"items": []interface{}{nil, nil, nil, map[string]interface{}{"price": "abc"}}}, "items[3].price"},
+		{map[string]interface{}{"index": map[string]interface{}{"k": map[string]interface{}{"price": "x"}}}, "index.k.price"},
+		{map[string]interface{}{"attrs": map[string]interface{}{"a": "x"}}, "attrs.a"},
+		{map[string]interface{}{"coupon": map[string]interface{}{"price": []int{}}}, "coupon.price"},
+		{map[string]interface{}{"note": []int{}}, "note"},
+		{map[string]interface{}{"items": 1}, "items"},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, input[%+v], path[%s]", i, tt.input, tt.path)
+
+		var v TestDecodeOrder
+		err := cvt.DecodeE(tt.input, &v)
+		var e *cvt.FieldError
+		assertEqual(t, true, errors.As(err, &e), "[FieldError] "+msg)
+		assertEqual(t, tt.path, e.Path, "[Path] "+msg)
+	}
+
+	var v TestDecodeOrder
+	err := cvt.DecodeE(map[string]interface{}{"items": []interface{}{map[string]interface{}{"price": "abc"}}}, &v)
+	assertEqual(t, `items[0].price: unable to convert "abc" of type string to float64`, err.Error())
+}
+
+func TestDecodeE_Target(t *testing.T) {
+	// keep the fields not in src
+	v1 := TestDecodeItem{Name: "a", Price: 1}
+	assertNoError(t, cvt.DecodeE(map[string]interface{}{"price": 2}, &v1))
+	assertEqual(t, TestDecodeItem{Name: "a", Price: 2}, v1)
+
+	// not struct
+	var v2 []TestDecodeItem
+	assertNoError(t, cvt.DecodeE(`[{"name": "a"}]`, &v2))
+	assertEqual(t, []TestDecodeItem{{Name: "a"}}, v2)
+
+	var v3 map[string]*TestDecodeItem
+	assertNoError(t, cvt.DecodeE(map[string]interface{}{"a": map[string]int{"price": 1}}, &v3))
+	assertEqual(t, map[string]*TestDecodeItem{"a": {Price: 1}}, v3)
+
+	var v4 time.Time
+	assertNoError(t, cvt.DecodeE(1234567890, &v4))
+	assertEqual(t, time.Unix(1234567890, 0), v4)
+
+	var v5 *TestDecodeItem
+	assertNoError(t, cvt.DecodeE(`{"name": "a"}`, &v5))
+	assertEqual(t, &TestDecodeItem{Name: "a"}, v5)
+
+	err := cvt.DecodeE(map[string]interface{}{"a": map[string]string{"price": "x"}}, &v3)
+	var e *cvt.FieldError
+	assertEqual(t, true, errors.As(err, &e))
+	assertEqual(t, "a.price", e.Path)
+
+	// array
+	var v6 struct {
+		Pair [2]int    `json:"pair"`
+		Ptr  *[2]int64 `json:"ptr"`
+		Raw  [3]byte   `json:"raw"`
+	}
+	assertNoError(t, cvt.DecodeE(`{"pair": [1, "2"], "ptr": [3, 4], "raw": "abc"}`, &v6))
+	assertEqual(t, [2]int{1, 2}, v6.Pair)
+	assertEqual(t, &[2]int64{3, 4}, v6.Ptr)
+	assertEqual(t, [3]byte{'a', 'b', 'c'}, v6.Raw)
+
+	var v7 [2]string
+	assertNoError(t, cvt.DecodeE([]int{1, 2}, &v7))
+	assertEqual(t, [2]string{"1", "2"}, v7)
+
+	err = cvt.DecodeE(`{"pair": [1, 2, 3]}`, &v6)
+	assertEqual(t, true, errors.As(err, &e))
+	assertEqual(t, "pair", e.Path)
+	assertEqual(t, `pair: unable to convert []interface {}{"1", "2", "3"} of type []interface {} to [2]int, length 3 does not match 2`, err.Error())
+
+	err = cvt.DecodeE(`{"pair": [1, "a"]}`, &v6)
+	assertEqual(t, true, errors.As(err, &e))
+	assertEqual(t, "pair[1]", e.Path)
+
+	// self embedded pointer, the embedded cycle is skipped
+	var v8 TestStructSelfEmbed
+	assertNoError(t, cvt.DecodeE(map[string]interface{}{"Name": "x"}, &v8))
+	assertEqual(t, TestStructSelfEmbed{Name: "x"}, v8)
+
+	// invalid dst
+	assertError(t, cvt.DecodeE(`{}`, nil))
+	assertError(t, cvt.DecodeE(`{}`, v1))
+	assertError(t, cvt.DecodeE(`{}`, (*TestDecodeItem)(nil)))
+}
+
+type TestCopyModel struct {
+	TestDecodeBase
+	Name  *string
+	Email string `json:"email"`
+	Score float64
+	Phone string
+}
+
+type TestCopyDTO struct {
+	ID      string `json:"id"`
+	Created int64  `json:"created"`
+	Name    string
+	Mail    string `json:"mail"`
+	Score   *int
+	PHONE   string
+	cache   int
+}
+
+func TestCopyE(t *testing.T) {
+	name := "bob"
+	score := 90
+	model := TestCopyModel{
+		TestDecodeBase: TestDecodeBase{ID: 1, Created: time.Unix(1234567890, 0)},
+		Name:           &name,
+		Email:          "bob@example.com",
+		Score:          90,
+		Phone:          "123",
+	}
+
+	// field name, tag, case-insensitive and explicit mapping
+	var dto TestCopyDTO
+	assertNoError(t, cvt.CopyE(&dto, model, map[string]string{"mail": "email"}))
+	assertEqual(t, TestCopyDTO{"1", 1234567890, "bob", "bob@example.com", &score, "123", 0}, dto)
+
+	// reverse, the embedded fields
+	var m TestCopyModel
+	assertNoError(t, cvt.CopyE(&m, &dto, map[string]string{"email": "mail"}))
+	assertEqual(t, model, m)
+
+	// unmapped fields are reported, the others are copied
+	var dto2 TestCopyDTO
+	err := cvt.CopyE(&dto2, model)
+	var ce *cvt.CopyError
+	assertEqual(t, true, errors.As(err, &ce))
+	assertEqual(t, []string{"mail"}, ce.Unmapped)
+	assertEqual(t, 0, len(ce.Errors))
+	assertEqual(t, "1", dto2.ID)
+	assertEqual(t, "unmapped fields: mail", err.Error())
+
+	// skip by "-"
+	assertNoError(t, cvt.CopyE(&dto2, model, map[string]string{"mail": "-"}))
+
+	// unconvertible fields
+	var m2 TestCopyModel
+	err = cvt.CopyE(&m2, TestCopyDTO{ID: "a", Name: "c"}, map[string]string{"email": "-"})
+	assertEqual(t, true, errors.As(err, &ce))
+	assertEqual(t, 0, len(ce.Unmapped))
+	assertEqual(t, 1, len(ce.Errors))
+	assertEqual(t, "id", ce.Errors[0].Path)
+	assertEqual(t, "c", *m2.Name)
+
+	// the unexported fields are skipped
+	src := struct {
+		Name  string
+		cache int
+	}{"a", 1}
+	var dst struct {
+		Name  string
+		cache int
+	}
+	assertNoError(t, cvt.CopyE(&dst, src))
+	assertEqual(t, "a", dst.Name)
+	assertEqual(t, 0, dst.cache)
+
+	// self embedded pointer, the embedded cycle is skipped
+	node := TestStructSelfEmbed{Name: "n"}
+	node.TestStructSelfEmbed = &node
+	var nodeDst TestStructSelfEmbed
+	assertNoError(t, cvt.CopyE(&nodeDst, node))
+	assertEqual(t, TestStructSelfEmbed{Name: "n"}, nodeDst)
+	assertNoError(t, cvt.CopyE(&TestStructSelfEmbed{}, TestStructSelfEmbed{}))
+
+	// invalid dst and src
+	assertError(t, cvt.CopyE(nil, model))
+	assertError(t, cvt.CopyE(dto, model))
+	assertError(t, cvt.CopyE((*TestCopyDTO)(nil), model))
+	assertError(t, cvt.CopyE(&name, model))
+	assertError(t, cvt.CopyE(&dto, 1))
+}
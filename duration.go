@@ -0,0 +1,236 @@
+package cvt
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DurationUnit the unit of number, while convert time.Duration to an integer or float
+// default is nanosecond, same as the underlying int64 of time.Duration
+// it applies to time.Duration and its pointer, the named type of time.Duration is an integer of nanoseconds
+// the unit <= 0 is treated as nanosecond
+var DurationUnit = time.Nanosecond
+
+// returns the DurationUnit, the unit <= 0 is treated as nanosecond
+func durationUnit() time.Duration {
+	if DurationUnit <= 0 {
+		return time.Nanosecond
+	}
+	return DurationUnit
+}
+
+// returns the time.Duration of val, include the pointer of time.Duration
+func indirectDuration(val interface{}) (time.Duration, bool) {
+	rv := reflect.ValueOf(val)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.IsValid() && rv.Type() == typeDuration {
+		return time.Duration(rv.Int()), true
+	}
+	return 0, false
+}
+
+// Duration convert an interface to a time.Duration type, with default value
+func Duration(v interface{}, def ...time.Duration) time.Duration {
+	if v, err := DurationE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return 0
+}
+
+// DurationE convert an interface to a time.Duration type
+// * number is treated as nanoseconds, same as time.Duration(n)
+// * string supports time.ParseDuration format, eg: "1h30m"
+// * string supports ISO 8601 duration without years and months, eg: "PT1H30M", "P1DT2H"
+func DurationE(val interface{}) (time.Duration, error) {
+	v, e := convDuration(val)
+	if e := catch("time.Duration", val, e); e != nil {
+		return 0, e
+	}
+
+	return v, nil
+}
+
+// convert any value to time.Duration
+func convDuration(val interface{}) (time.Duration, error) {
+	// direct type(for improve performance)
+	switch vv := val.(type) {
+	case nil:
+		return 0, nil
+	case time.Duration:
+		return vv, nil
+	case string:
+		return str2duration(vv)
+	case []byte:
+		return str2duration(string(vv))
+	case json.Number:
+		return str2duration(vv.String())
+	}
+
+	// indirect type
+	v, _ := indirectScalar(val)
+	switch vv := v.(type) {
+	case nil:
+		return 0, nil
+	case string:
+		return str2duration(vv)
+	case []byte:
+		return str2duration(string(vv))
+	}
+
+	i, err := convInt64(v)
+	return time.Duration(i), err
+}
+
+// convert a number, Go duration or ISO 8601 duration string to time.Duration
+//
+//	"12" => 12ns
+//	"1h30m" => 1h30m0s
+//	"PT1H30M" => 1h30m0s
+func str2duration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if i, err := str2int64(s); err == nil {
+		return time.Duration(i), nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, nil
+	}
+
+	p, err := parsePeriod(s)
+	if err != nil {
+		return 0, err
+	}
+	// the length of years and months depends on the date
+	if p.years != 0 || p.months != 0 {
+		return 0, fmt.Errorf("inexact duration: %s", s)
+	}
+	if int64(p.days) > math.MaxInt64/int64(24*time.Hour) {
+		return 0, fmt.Errorf("duration out of range: %s", s)
+	}
+	d := time.Duration(p.days) * 24 * time.Hour
+	if d > math.MaxInt64-p.clock {
+		return 0, fmt.Errorf("duration out of range: %s", s)
+	}
+
+	return d + p.clock, nil
+}
+
+// period is the ISO 8601 duration,
+// the date part is kept separately, because its length depends on the date it is applied to
+type period struct {
+	years, months, days int
+	clock               time.Duration
+}
+
+// addTo returns the time t+p, or t-p while neg is true
+func (p period) addTo(t time.Time, neg bool) time.Time {
+	if neg {
+		return t.AddDate(-p.years, -p.months, -p.days).Add(-p.clock)
+	}
+	return t.AddDate(p.years, p.months, p.days).Add(p.clock)
+}
+
+// the max value of int
+const maxInt = int(^uint(0) >> 1)
+
+// parse an ISO 8601 duration, like "P1Y2M3DT4H5M6.5S" or "P2W"
+// only the time elements(hours, minutes, seconds) support fraction
+func parsePeriod(s string) (p period, err error) {
+	errInvalid := fmt.Errorf("unable to parse duration: %s", s)
+	errRange := fmt.Errorf("duration out of range: %s", s)
+	if len(s) < 2 || (s[0] != 'P' && s[0] != 'p') {
+		return p, errInvalid
+	}
+
+	var inTime bool
+	var unit int // index of the last parsed unit, keep the designators in order
+	rest := s[1:]
+	for rest != "" {
+		if rest[0] == 'T' || rest[0] == 't' {
+			if inTime || len(rest) == 1 {
+				return p, errInvalid
+			}
+			inTime = true
+			unit = 0
+			rest = rest[1:]
+			continue
+		}
+
+		// the number, the decimal sign can be "." or ","
+		j := 0
+		for j < len(rest) && (rest[j] >= '0' && rest[j] <= '9' || rest[j] == '.' || rest[j] == ',') {
+			j++
+		}
+		if j == 0 || j == len(rest) {
+			return p, errInvalid
+		}
+		num := strings.Replace(rest[:j], ",", ".", 1)
+		designator := strings.ToUpper(rest[j : j+1])
+		rest = rest[j+1:]
+
+		units := "YMWD"
+		if inTime {
+			units = "HMS"
+		}
+		idx := strings.Index(units, designator)
+		if idx < 0 || idx+1 <= unit {
+			return p, errInvalid
+		}
+		unit = idx + 1
+
+		if !inTime {
+			n, e := strconv.Atoi(num)
+			if e != nil {
+				return p, errInvalid
+			}
+			switch designator {
+			case "Y":
+				p.years = n
+			case "M":
+				p.months = n
+			case "W", "D":
+				days := 1
+				if designator == "W" {
+					days = 7
+				}
+				if n > (maxInt-p.days)/days {
+					return p, errRange
+				}
+				p.days += n * days
+			}
+			continue
+		}
+
+		f, e := strconv.ParseFloat(num, 64)
+		if e != nil {
+			return p, errInvalid
+		}
+		var d float64
+		switch designator {
+		case "H":
+			d = f * float64(time.Hour)
+		case "M":
+			d = f * float64(time.Minute)
+		case "S":
+			d = f * float64(time.Second)
+		}
+		// float64(math.MaxInt64) is rounded up to 2^63
+		if d >= float64(math.MaxInt64) || time.Duration(d) > math.MaxInt64-p.clock {
+			return p, errRange
+		}
+		p.clock += time.Duration(d)
+	}
+
+	return p, nil
+}
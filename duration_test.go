@@ -0,0 +1,115 @@
+package cvt_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/shockerli/cvt"
+)
+
+func TestDuration_HasDefault(t *testing.T) {
+	tests := []struct {
+		input  interface{}
+		def    time.Duration
+		expect time.Duration
+	}{
+		// supported value, def is not used, def != expect
+		{"1h", time.Second, time.Hour},
+		{"PT1H", time.Second, time.Hour},
+
+		// unsupported value, def == expect
+		{"hello", time.Second, time.Second},
+		{"P1M", time.Second, time.Second},
+		{testing.T{}, time.Second, time.Second},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, input[%+v], def[%+v], expect[%+v]", i, tt.input, tt.def, tt.expect)
+
+		v := cvt.Duration(tt.input, tt.def)
+		assertEqual(t, tt.expect, v, "[NonE] "+msg)
+	}
+}
+
+func TestDurationE(t *testing.T) {
+	tests := []struct {
+		input  interface{}
+		expect time.Duration
+		isErr  bool
+	}{
+		{nil, 0, false},
+		{time.Minute, time.Minute, false},
+		{12, 12, false},
+		{int64(12), 12, false},
+		{uint8(12), 12, false},
+		{12.9, 12, false},
+		{true, 1, false},
+		{"12", 12, false},
+		{"12.9", 12, false},
+		{json.Number("12"), 12, false},
+		{aliasTypeInt1, 1, false},
+		{&aliasTypeInt1, 1, false},
+		{pointerIntNil, 0, false},
+
+		// Go duration
+		{"1h30m", 90 * time.Minute, false},
+		{" 1h30m ", 90 * time.Minute, false},
+		{"-1.5s", -1500 * time.Millisecond, false},
+		{[]byte("300ms"), 300 * time.Millisecond, false},
+		{AliasTypeString("2h"), 2 * time.Hour, false},
+
+		// ISO 8601 duration
+		{"PT1H30M", 90 * time.Minute, false},
+		{"PT0.5S", 500 * time.Millisecond, false},
+		{"PT0,5S", 500 * time.Millisecond, false},
+		{"P1D", 24 * time.Hour, false},
+		{"P1DT2H", 26 * time.Hour, false},
+		{"P2W", 14 * 24 * time.Hour, false},
+		{"PT36H", 36 * time.Hour, false},
+		{"pt1m", time.Minute, false},
+		{"P106751D", 106751 * 24 * time.Hour, false},
+		{"PT2562047H", 2562047 * time.Hour, false},
+
+		// errors
+		{"P1Y", 0, true},
+		{"P1M", 0, true},
+		{"P", 0, true},
+		{"PT", 0, true},
+		{"P1DT", 0, true},
+		{"PT1M1H", 0, true},
+		{"P1H", 0, true},
+		{"PT1D", 0, true},
+		{"P1.5D", 0, true},
+		{"P1", 0, true},
+		{"P200000D", 0, true},
+		{"P200000W", 0, true},
+		{"P106751DT24H", 0, true},
+		{"PT9999999999999H", 0, true},
+		{"PT2562047H60M", 0, true},
+		{"P9223372036854775807W", 0, true},
+		{"hello", 0, true},
+		{testing.T{}, 0, true},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf(
+			"i = %d, input[%+v], expect[%+v], isErr[%v]",
+			i, tt.input, tt.expect, tt.isErr,
+		)
+
+		v, err := cvt.DurationE(tt.input)
+		if tt.isErr {
+			assertError(t, err, "[HasErr] "+msg)
+			continue
+		}
+
+		assertNoError(t, err, "[NoErr] "+msg)
+		assertEqual(t, tt.expect, v, "[WithE] "+msg)
+
+		// Non-E test
+		v = cvt.Duration(tt.input)
+		assertEqual(t, tt.expect, v, "[NonE] "+msg)
+	}
+}
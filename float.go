@@ -2,9 +2,12 @@ package cvt
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
+	"math/big"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -28,8 +31,12 @@ func Float64P(v interface{}, def ...float64) *float64 {
 }
 
 // Float64E convert an interface to a float64 type
+// NaN and ±Inf are handled by NonFinite
 func Float64E(val interface{}) (float64, error) {
 	v, e := convFloat64E(val)
+	if e == nil {
+		v, e = checkNonFinite(v)
+	}
 	if e := catch("float64", val, e); e != nil {
 		return 0, e
 	}
@@ -63,19 +70,30 @@ func convFloat64E(val interface{}) (float64, error) {
 	case int, int8, int16, int32, int64:
 		return float64(Int(vv)), nil
 	case float32:
-		// use fmt to fix float32 -> float64 precision loss
-		// eg: cvt.Float64E(float32(8.31))
-		return strconv.ParseFloat(fmt.Sprintf("%f", vv), 64)
+		return float32to64(vv), nil
 	case float64:
 		return vv, nil
 	case json.Number:
 		return vv.Float64()
 	case time.Duration:
-		return float64(vv), nil
+		return float64(vv) / float64(durationUnit()), nil
+	case time.Time:
+		return time2float64(vv), nil
+	case *big.Int, *big.Float, *big.Rat:
+		f, err := convBigFloat(vv)
+		if err != nil {
+			return 0, err
+		}
+		return bigFloat2float64(f)
+	}
+
+	// the pointer of time.Duration
+	if d, ok := indirectDuration(val); ok {
+		return float64(d) / float64(durationUnit()), nil
 	}
 
 	// indirect type
-	v, rv := Indirect(val)
+	v, rv := indirectScalar(val)
 
 	switch vv := v.(type) {
 	case nil:
@@ -94,16 +112,68 @@ func convFloat64E(val interface{}) (float64, error) {
 	case int, int8, int16, int32, int64:
 		return float64(rv.Int()), nil
 	case float32:
-		// use fmt to fix float32 -> float64 precision loss
-		// eg: cvt.Float64E(float32(8.31))
-		return strconv.ParseFloat(fmt.Sprintf("%f", vv), 64)
+		return float32to64(vv), nil
 	case float64:
 		return vv, nil
+	case time.Time:
+		return time2float64(vv), nil
+	case big.Int, big.Float, big.Rat:
+		f, err := convBigFloat(vv)
+		if err != nil {
+			return 0, err
+		}
+		return bigFloat2float64(f)
 	}
 
 	return 0, errConvFail
 }
 
+// NonFinitePolicy the policy of NaN and ±Inf in the numeric, complex, bool and string conversion
+type NonFinitePolicy int
+
+// the policies of NaN and ±Inf
+const (
+	NonFinitePass   NonFinitePolicy = iota // keep as is: float keeps it, int returns an error, bool is true, string is "NaN"/"+Inf"/"-Inf"
+	NonFiniteReject                        // returns an error
+	NonFiniteZero                          // treat as 0: float and int are 0, bool is false, string is "0"
+)
+
+// NonFinite the policy of NaN and ±Inf, default NonFinitePass
+// it applies to the float inputs, and the strings parsed as float, eg: "NaN", "Inf"
+var NonFinite = NonFinitePass
+
+var errNonFinite = errors.New("NaN or Inf is not allowed")
+
+// apply the NonFinite policy to the float
+func checkNonFinite(f float64) (float64, error) {
+	if !math.IsNaN(f) && !math.IsInf(f, 0) {
+		return f, nil
+	}
+	switch NonFinite {
+	case NonFiniteReject:
+		return 0, errNonFinite
+	case NonFiniteZero:
+		return 0, nil
+	}
+	return f, nil
+}
+
+// Float32RawWidening widen the float32 to float64 by the binary value, eg: float32(8.31) => 8.3100004196167
+// default false, widen by the shortest decimal representation, eg: float32(8.31) => 8.31
+var Float32RawWidening = false
+
+// widen float32 to float64, keep the shortest decimal representation of float32
+//
+//	float32(8.31) => 8.31, not 8.3100004196167
+//	float32(1.5e-7) => 1.5e-7
+func float32to64(f float32) float64 {
+	if Float32RawWidening || math.IsNaN(float64(f)) || math.IsInf(float64(f), 0) {
+		return float64(f)
+	}
+	v, _ := strconv.ParseFloat(strconv.FormatFloat(float64(f), 'g', -1, 32), 64)
+	return v
+}
+
 // Float32 convert an interface to a float32 type, with default value
 func Float32(v interface{}, def ...float32) float32 {
 	if v, err := Float32E(v); err == nil {
@@ -124,14 +194,125 @@ func Float32P(v interface{}, def ...float32) *float32 {
 }
 
 // Float32E convert an interface to a float32 type
+// NaN and ±Inf are handled by NonFinite
 func Float32E(val interface{}) (float32, error) {
 	v, e := convFloat64E(val)
+	if e == nil {
+		v, e = checkNonFinite(v)
+	}
 	if e := catch("float32", val, e); e != nil {
 		return 0, e
 	}
-	if v > math.MaxFloat32 {
+	// the shortest widening of float32 may be a bit larger than math.MaxFloat32, eg: 3.4028235e+38
+	if v > math.MaxFloat32 && !math.IsInf(v, 1) && math.IsInf(float64(float32(v)), 1) {
 		return 0, fmt.Errorf(formatOutOfLimitFloat, newErr(val, "float32"), float32(math.MaxFloat32))
 	}
 
 	return float32(v), nil
 }
+
+// FloatFormat the options of formatting float to string, used by StringE, SliceStringE, StringMapE and Converter
+// build from DefaultFloatFormat, or all the fields should be set, the zero value is same as DefaultFloatFormat
+//
+//	ff := cvt.DefaultFloatFormat
+//	ff.Prec = 2
+//	ff.StringE(3.14159) // "3.14"
+type FloatFormat struct {
+	Verb      byte   // the format of strconv.FormatFloat, 'f', 'e', 'E', 'g' or 'G', default 'f'
+	Prec      int    // the precision of strconv.FormatFloat, -1 for the smallest number of digits necessary
+	TrimZeros bool   // trim the trailing zeros of decimal part, eg: "1.50" => "1.5", "2.00" => "2"
+	Grouping  string // the thousands separator of integer part, eg: "," for "1,234,567.89"
+	NaN       string // the spelling of NaN, default "NaN"
+	PosInf    string // the spelling of +Inf, default "+Inf"
+	NegInf    string // the spelling of -Inf, default "-Inf"
+}
+
+// DefaultFloatFormat the default float format used by StringE, same as strconv.FormatFloat(f, 'f', -1, bitSize)
+// you can change this for global
+var DefaultFloatFormat = FloatFormat{Verb: 'f', Prec: -1}
+
+// Format returns the string of float f, with bitSize 32 or 64
+func (ff FloatFormat) Format(f float64, bitSize int) string {
+	if ff == (FloatFormat{}) {
+		ff = DefaultFloatFormat
+	}
+
+	switch {
+	case math.IsNaN(f):
+		return spell(ff.NaN, "NaN")
+	case math.IsInf(f, 1):
+		return spell(ff.PosInf, "+Inf")
+	case math.IsInf(f, -1):
+		return spell(ff.NegInf, "-Inf")
+	}
+
+	verb := ff.Verb
+	if verb == 0 {
+		verb = 'f'
+	}
+	s := strconv.FormatFloat(f, verb, ff.Prec, bitSize)
+
+	// split the sign, integer part, decimal part and exponent
+	var sign, exp string
+	if s[0] == '-' || s[0] == '+' {
+		sign, s = s[:1], s[1:]
+	}
+	if i := strings.IndexAny(s, "eEpP"); i >= 0 {
+		s, exp = s[:i], s[i:]
+	}
+	intPart, decPart := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, decPart = s[:i], s[i+1:]
+	}
+
+	if ff.TrimZeros {
+		decPart = strings.TrimRight(decPart, "0")
+	}
+	if ff.Grouping != "" {
+		intPart = groupDigits(intPart, ff.Grouping)
+	}
+
+	s = sign + intPart
+	if decPart != "" {
+		s += "." + decPart
+	}
+	return s + exp
+}
+
+// format the float, NaN and ±Inf are handled by NonFinite
+func (ff FloatFormat) format(f float64, bitSize int) (string, error) {
+	f, err := checkNonFinite(f)
+	if err != nil {
+		return "", err
+	}
+	return ff.Format(f, bitSize), nil
+}
+
+func spell(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
+
+// insert the separator every 3 digits from the right
+//
+//	"1234567" => "1,234,567"
+func groupDigits(s string, sep string) string {
+	if len(s) <= 3 {
+		return s
+	}
+
+	var b strings.Builder
+	head := len(s) % 3
+	if head > 0 {
+		b.WriteString(s[:head])
+	}
+	for j := head; j < len(s); j += 3 {
+		if b.Len() > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteString(s[j : j+3])
+	}
+	return b.String()
+}
@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"math/big"
+	"strconv"
 	"testing"
+	"testing/quick"
 	"time"
 
 	"github.com/shockerli/cvt"
@@ -307,6 +310,9 @@ func TestFloat64E(t *testing.T) {
 		{aliasTypeFloat8d15, 8.15, false},
 		{&aliasTypeFloat8d15, 8.15, false},
 		{time.Duration(1), 1, false},
+		{time1, 1234567890, false},
+		{&time1, 1234567890, false},
+		{time1.Add(5e8), 1234567890.5, false},
 
 		// errors
 		{"10a", 0, true},
@@ -344,6 +350,116 @@ func TestFloat64E(t *testing.T) {
 	}
 }
 
+func TestFloat64E_TimestampUnit(t *testing.T) {
+	defer func(unit, durUnit time.Duration) {
+		cvt.TimestampUnit, cvt.DurationUnit = unit, durUnit
+	}(cvt.TimestampUnit, cvt.DurationUnit)
+
+	var dur = 90 * time.Minute
+	tests := []struct {
+		unit   time.Duration
+		input  interface{}
+		expect float64
+	}{
+		{time.Second, time1.Add(25e7), 1234567890.25},
+		{time.Millisecond, time1.Add(25e7), 1234567890250},
+		{time.Microsecond, time1.Add(1500), 1234567890000001.5},
+		{time.Second, 1500 * time.Millisecond, 1.5},
+		{time.Millisecond, 1500 * time.Millisecond, 1500},
+		{time.Hour, 90 * time.Minute, 1.5},
+		{time.Hour, &dur, 1.5},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, unit[%s], input[%+v], expect[%v]", i, tt.unit, tt.input, tt.expect)
+
+		cvt.TimestampUnit, cvt.DurationUnit = tt.unit, tt.unit
+		v, err := cvt.Float64E(tt.input)
+		assertNoError(t, err, "[NoErr] "+msg)
+		assertEqual(t, tt.expect, v, "[WithE] "+msg)
+	}
+}
+
+func TestFloat64E_Float32Widening(t *testing.T) {
+	defer func(raw bool) {
+		cvt.Float32RawWidening = raw
+	}(cvt.Float32RawWidening)
+
+	tests := []struct {
+		raw    bool
+		input  interface{}
+		expect float64
+	}{
+		{false, float32(8.31), 8.31},
+		{false, float32(1.5e-7), 1.5e-7},
+		{false, float32(-1.5e-7), -1.5e-7},
+		{false, float32(16777217), 16777216},
+		{false, float32(math.MaxFloat32), 3.4028235e+38},
+		{false, float32(math.SmallestNonzeroFloat32), 1e-45},
+		{false, AliasTypeFloat32(8.15), 8.15},
+		{false, float32(math.Inf(-1)), math.Inf(-1)},
+		{true, float32(8.31), float64(float32(8.31))},
+		{true, float32(1.5e-7), float64(float32(1.5e-7))},
+		{true, float32(math.MaxFloat32), math.MaxFloat32},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, raw[%v], input[%+v], expect[%v]", i, tt.raw, tt.input, tt.expect)
+
+		cvt.Float32RawWidening = tt.raw
+		v, err := cvt.Float64E(tt.input)
+		assertNoError(t, err, "[NoErr] "+msg)
+		assertEqual(t, tt.expect, v, "[WithE] "+msg)
+	}
+}
+
+// the widened float64 must keep the shortest representation of float32, and round-trip to the same float32
+func TestFloat64E_Float32RoundTrip(t *testing.T) {
+	check := func(bits uint32) bool {
+		f := math.Float32frombits(bits)
+		if math.IsNaN(float64(f)) {
+			return true
+		}
+
+		v, err := cvt.Float64E(f)
+		if err != nil || float32(v) != f {
+			return false
+		}
+		if strconv.FormatFloat(v, 'g', -1, 64) != strconv.FormatFloat(float64(f), 'g', -1, 32) {
+			return false
+		}
+		f2, err := cvt.Float32E(v)
+		return err == nil && math.Float32bits(f2) == bits
+	}
+
+	// the boundaries of every exponent, include subnormal, zero and infinity
+	for exp := uint32(0); exp <= 0xff; exp++ {
+		for _, mant := range []uint32{0, 1, 2, 0x3fffff, 0x400000, 0x7ffffe, 0x7fffff} {
+			for _, sign := range []uint32{0, 1 << 31} {
+				if bits := sign | exp<<23 | mant; !check(bits) {
+					t.Fatalf("round-trip failed, bits[%#08x], value[%v]", bits, math.Float32frombits(bits))
+				}
+			}
+		}
+	}
+
+	// the whole float32 range with a prime stride
+	stride := uint64(7919)
+	if testing.Short() {
+		stride = 104729
+	}
+	for bits := uint64(0); bits <= math.MaxUint32; bits += stride {
+		if !check(uint32(bits)) {
+			t.Fatalf("round-trip failed, bits[%#08x], value[%v]", bits, math.Float32frombits(uint32(bits)))
+		}
+	}
+
+	// random
+	if err := quick.Check(check, &quick.Config{MaxCount: 100000}); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestFloat32E(t *testing.T) {
 	tests := []struct {
 		input  interface{}
@@ -362,6 +478,8 @@ func TestFloat32E(t *testing.T) {
 		{uint64(8), 8, false},
 		{float32(8.31), float32(8.31), false},
 		{float64(8.31), float32(8.31), false},
+		{float32(math.MaxFloat32), float32(math.MaxFloat32), false},
+		{float32(1.5e-7), float32(1.5e-7), false},
 		{true, 1, false},
 		{false, 0, false},
 		{int(-8), -8, false},
@@ -443,3 +561,163 @@ func TestFloat32E(t *testing.T) {
 		assertEqual(t, tt.expect, v, "[NonE] "+msg)
 	}
 }
+
+func TestNonFinite(t *testing.T) {
+	defer func(policy cvt.NonFinitePolicy) {
+		cvt.NonFinite = policy
+	}(cvt.NonFinite)
+
+	nan, inf := math.NaN(), math.Inf(1)
+	conv := map[string]func(interface{}) (interface{}, error){
+		"float64":    func(v interface{}) (interface{}, error) { return cvt.Float64E(v) },
+		"float32":    func(v interface{}) (interface{}, error) { return cvt.Float32E(v) },
+		"int64":      func(v interface{}) (interface{}, error) { return cvt.Int64E(v) },
+		"uint64":     func(v interface{}) (interface{}, error) { return cvt.Uint64E(v) },
+		"int":        func(v interface{}) (interface{}, error) { return cvt.IntE(v) },
+		"bool":       func(v interface{}) (interface{}, error) { return cvt.BoolE(v) },
+		"string":     func(v interface{}) (interface{}, error) { return cvt.StringE(v) },
+		"complex128": func(v interface{}) (interface{}, error) { return cvt.Complex128E(v) },
+		"complex64":  func(v interface{}) (interface{}, error) { return cvt.Complex64E(v) },
+		"decimal": func(v interface{}) (interface{}, error) {
+			d, err := cvt.DecimalE(v)
+			if err != nil {
+				return nil, err
+			}
+			return d.String(), nil
+		},
+		"bigInt": func(v interface{}) (interface{}, error) {
+			i, err := cvt.BigIntE(v)
+			if err != nil {
+				return nil, err
+			}
+			return i.String(), nil
+		},
+		"bigRat": func(v interface{}) (interface{}, error) {
+			r, err := cvt.BigRatE(v)
+			if err != nil {
+				return nil, err
+			}
+			return r.RatString(), nil
+		},
+		"bigFloat": func(v interface{}) (interface{}, error) {
+			f, err := cvt.BigFloatE(v)
+			if err != nil {
+				return nil, err
+			}
+			return f.String(), nil
+		},
+	}
+
+	tests := []struct {
+		policy cvt.NonFinitePolicy
+		to     string
+		input  interface{}
+		expect interface{}
+		isErr  bool
+	}{
+		// pass
+		{cvt.NonFinitePass, "float64", inf, inf, false},
+		{cvt.NonFinitePass, "float64", "-Inf", math.Inf(-1), false},
+		{cvt.NonFinitePass, "float64", json.Number("Infinity"), inf, false},
+		{cvt.NonFinitePass, "float32", float32(inf), float32(inf), false},
+		{cvt.NonFinitePass, "float32", math.Inf(-1), float32(math.Inf(-1)), false},
+		{cvt.NonFinitePass, "int64", nan, nil, true},
+		{cvt.NonFinitePass, "int64", inf, nil, true},
+		{cvt.NonFinitePass, "int64", float32(math.Inf(-1)), nil, true},
+		{cvt.NonFinitePass, "int64", "NaN", nil, true},
+		{cvt.NonFinitePass, "uint64", nan, nil, true},
+		{cvt.NonFinitePass, "uint64", AliasTypeFloat64(inf), nil, true},
+		{cvt.NonFinitePass, "int", "Inf", nil, true},
+		{cvt.NonFinitePass, "bool", nan, true, false},
+		{cvt.NonFinitePass, "bool", "-inf", true, false},
+		{cvt.NonFinitePass, "string", nan, "NaN", false},
+		{cvt.NonFinitePass, "string", float32(inf), "+Inf", false},
+		{cvt.NonFinitePass, "complex128", complex(inf, 1), complex(inf, 1), false},
+		{cvt.NonFinitePass, "complex64", "(1+Infi)", complex64(complex(1, inf)), false},
+		{cvt.NonFinitePass, "bigFloat", inf, "+Inf", false},
+		{cvt.NonFinitePass, "bigFloat", nan, nil, true},
+		{cvt.NonFinitePass, "string", complex(nan, 1), "(NaN+1i)", false},
+		{cvt.NonFinitePass, "decimal", nan, nil, true},
+		{cvt.NonFinitePass, "decimal", new(big.Float).SetInf(true), nil, true},
+		{cvt.NonFinitePass, "bigInt", inf, nil, true},
+		{cvt.NonFinitePass, "bigInt", new(big.Float).SetInf(false), nil, true},
+		{cvt.NonFinitePass, "bigRat", nan, nil, true},
+		{cvt.NonFinitePass, "bigRat", float32(inf), nil, true},
+
+		// reject
+		{cvt.NonFiniteReject, "float64", nan, nil, true},
+		{cvt.NonFiniteReject, "float64", "NaN", nil, true},
+		{cvt.NonFiniteReject, "float64", 8.31, 8.31, false},
+		{cvt.NonFiniteReject, "float32", inf, nil, true},
+		{cvt.NonFiniteReject, "int64", nan, nil, true},
+		{cvt.NonFiniteReject, "uint64", "Inf", nil, true},
+		{cvt.NonFiniteReject, "bool", nan, nil, true},
+		{cvt.NonFiniteReject, "bool", AliasTypeFloat64(inf), nil, true},
+		{cvt.NonFiniteReject, "bool", json.Number("NaN"), nil, true},
+		{cvt.NonFiniteReject, "bool", "NaN", nil, true},
+		{cvt.NonFiniteReject, "string", math.Inf(-1), nil, true},
+		{cvt.NonFiniteReject, "string", "NaN", "NaN", false},
+		{cvt.NonFiniteReject, "complex128", complex(nan, 0), nil, true},
+		{cvt.NonFiniteReject, "complex128", []float64{1, inf}, nil, true},
+		{cvt.NonFiniteReject, "complex64", "NaN", nil, true},
+		{cvt.NonFiniteReject, "complex128", complex(1, 2), complex(1, 2), false},
+		{cvt.NonFiniteReject, "bigFloat", inf, nil, true},
+		{cvt.NonFiniteReject, "bigFloat", "-Inf", nil, true},
+		{cvt.NonFiniteReject, "bigFloat", new(big.Float).SetInf(false), nil, true},
+		{cvt.NonFiniteReject, "bigFloat", 1.5, "1.5", false},
+		{cvt.NonFiniteReject, "string", complex(nan, 0), nil, true},
+		{cvt.NonFiniteReject, "string", complex64(complex(1, inf)), nil, true},
+		{cvt.NonFiniteReject, "string", complex(1, 2), "(1+2i)", false},
+		{cvt.NonFiniteReject, "decimal", inf, nil, true},
+		{cvt.NonFiniteReject, "decimal", 1.5, "1.5", false},
+		{cvt.NonFiniteReject, "bigInt", nan, nil, true},
+		{cvt.NonFiniteReject, "bigInt", new(big.Float).SetInf(true), nil, true},
+		{cvt.NonFiniteReject, "bigRat", inf, nil, true},
+		{cvt.NonFiniteReject, "bigRat", new(big.Float).SetInf(false), nil, true},
+
+		// zero
+		{cvt.NonFiniteZero, "float64", nan, float64(0), false},
+		{cvt.NonFiniteZero, "float64", "-Inf", float64(0), false},
+		{cvt.NonFiniteZero, "float32", inf, float32(0), false},
+		{cvt.NonFiniteZero, "int64", nan, int64(0), false},
+		{cvt.NonFiniteZero, "int64", "Inf", int64(0), false},
+		{cvt.NonFiniteZero, "uint64", float32(inf), uint64(0), false},
+		{cvt.NonFiniteZero, "int", math.Inf(-1), 0, false},
+		{cvt.NonFiniteZero, "bool", nan, false, false},
+		{cvt.NonFiniteZero, "bool", "NaN", false, false},
+		{cvt.NonFiniteZero, "string", inf, "0", false},
+		{cvt.NonFiniteZero, "complex128", complex(nan, 2), complex(0, 2), false},
+		{cvt.NonFiniteZero, "complex64", complex(1, inf), complex64(1), false},
+		{cvt.NonFiniteZero, "bigFloat", inf, "0", false},
+		{cvt.NonFiniteZero, "bigFloat", nan, "0", false},
+		{cvt.NonFiniteZero, "string", complex(nan, 2), "(0+2i)", false},
+		{cvt.NonFiniteZero, "decimal", nan, "0", false},
+		{cvt.NonFiniteZero, "decimal", float32(inf), "0", false},
+		{cvt.NonFiniteZero, "decimal", new(big.Float).SetInf(false), "0", false},
+		{cvt.NonFiniteZero, "bigInt", math.Inf(-1), "0", false},
+		{cvt.NonFiniteZero, "bigInt", new(big.Float).SetInf(true), "0", false},
+		{cvt.NonFiniteZero, "bigRat", nan, "0", false},
+		{cvt.NonFiniteZero, "bigRat", new(big.Float).SetInf(false), "0", false},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, policy[%v], to[%s], input[%+v], expect[%v], isErr[%v]", i, tt.policy, tt.to, tt.input, tt.expect, tt.isErr)
+
+		cvt.NonFinite = tt.policy
+		v, err := conv[tt.to](tt.input)
+		if tt.isErr {
+			assertError(t, err, "[HasErr] "+msg)
+			continue
+		}
+
+		assertNoError(t, err, "[NoErr] "+msg)
+		assertEqual(t, tt.expect, v, "[WithE] "+msg)
+	}
+
+	// the error is wrapped with the input and target type
+	cvt.NonFinite = cvt.NonFiniteReject
+	_, err := cvt.StringE(inf)
+	assertEqual(t, "unable to convert +Inf of type float64 to string, NaN or Inf is not allowed", fmt.Sprint(err), "[StringE] wrap error")
+	_, err = cvt.Complex128E(complex(nan, 0))
+	assertEqual(t, "unable to convert (NaN+0i) of type complex128 to complex128, NaN or Inf is not allowed", fmt.Sprint(err), "[Complex128E] wrap error")
+}
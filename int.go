@@ -3,8 +3,10 @@ package cvt
 import (
 	"fmt"
 	"math"
+	"math/big"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Uint64 convert an interface to an uint64 type, with default value
@@ -362,15 +364,9 @@ func convUint64(val interface{}) (uint64, error) {
 	case uint8:
 		return uint64(vv), nil
 	case float64:
-		if vv > math.MaxUint64 || vv < 0 {
-			return 0, errConvFail
-		}
-		return uint64(math.Trunc(vv)), nil
+		return float2uint64(vv)
 	case float32:
-		if vv > math.MaxUint64 || vv < 0 {
-			return 0, errConvFail
-		}
-		return uint64(vv), nil
+		return float2uint64(float64(vv))
 	case nil:
 		return 0, nil
 	case bool:
@@ -382,10 +378,31 @@ func convUint64(val interface{}) (uint64, error) {
 		return str2uint64(vv)
 	case []byte:
 		return str2uint64(string(vv))
+	case time.Time:
+		return time2uint64(vv)
+	case time.Duration:
+		if vv < 0 {
+			return 0, errConvFail
+		}
+		return uint64(vv / durationUnit()), nil
+	case *big.Int, *big.Float, *big.Rat:
+		i, err := convBigInt(vv)
+		if err != nil {
+			return 0, err
+		}
+		return bigInt2uint64(i)
+	}
+
+	// the pointer of time.Duration
+	if d, ok := indirectDuration(val); ok {
+		if d < 0 {
+			return 0, errConvFail
+		}
+		return uint64(d / durationUnit()), nil
 	}
 
 	// indirect type
-	v, rv := Indirect(val)
+	v, rv := indirectScalar(val)
 	switch vv := v.(type) {
 	case nil:
 		return 0, nil
@@ -404,10 +421,16 @@ func convUint64(val interface{}) (uint64, error) {
 		if rv.Int() >= 0 {
 			return uint64(rv.Int()), nil
 		}
-	case float32, float64:
-		if rv.Float() >= 0 && rv.Float() <= math.MaxUint64 {
-			return uint64(math.Trunc(rv.Float())), nil
+	case time.Time:
+		return time2uint64(vv)
+	case big.Int, big.Float, big.Rat:
+		i, err := convBigInt(vv)
+		if err != nil {
+			return 0, err
 		}
+		return bigInt2uint64(i)
+	case float32, float64:
+		return float2uint64(rv.Float())
 	}
 
 	return 0, errConvFail
@@ -444,12 +467,9 @@ func convInt64(val interface{}) (int64, error) {
 	case uint8:
 		return int64(vv), nil
 	case float64:
-		if vv > math.MaxInt64 {
-			return 0, errConvFail
-		}
-		return int64(math.Trunc(vv)), nil
+		return float2int64(vv)
 	case float32:
-		return int64(vv), nil
+		return float2int64(float64(vv))
 	case nil:
 		return 0, nil
 	case bool:
@@ -461,10 +481,25 @@ func convInt64(val interface{}) (int64, error) {
 		return str2int64(vv)
 	case []byte:
 		return str2int64(string(vv))
+	case time.Time:
+		return time2int64(vv)
+	case time.Duration:
+		return int64(vv / durationUnit()), nil
+	case *big.Int, *big.Float, *big.Rat:
+		i, err := convBigInt(vv)
+		if err != nil {
+			return 0, err
+		}
+		return bigInt2int64(i)
+	}
+
+	// the pointer of time.Duration
+	if d, ok := indirectDuration(val); ok {
+		return int64(d / durationUnit()), nil
 	}
 
 	// indirect type
-	v, rv := Indirect(val)
+	v, rv := indirectScalar(val)
 	switch vv := v.(type) {
 	case nil:
 		return 0, nil
@@ -483,10 +518,16 @@ func convInt64(val interface{}) (int64, error) {
 		}
 	case int, int8, int16, int32, int64:
 		return rv.Int(), nil
-	case float32, float64:
-		if rv.Float() <= math.MaxInt64 {
-			return int64(math.Trunc(rv.Float())), nil
+	case time.Time:
+		return time2int64(vv)
+	case big.Int, big.Float, big.Rat:
+		i, err := convBigInt(vv)
+		if err != nil {
+			return 0, err
 		}
+		return bigInt2int64(i)
+	case float32, float64:
+		return float2int64(rv.Float())
 	}
 
 	return 0, errConvFail
@@ -500,10 +541,13 @@ func convInt64(val interface{}) (int64, error) {
 //	"-12.01" => -12
 func str2int64(s string) (i int64, err error) {
 	// ensure can be converted to float
-	_, err = strconv.ParseFloat(s, 64)
+	f, err := strconv.ParseFloat(s, 64)
 	if err != nil {
 		return
 	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return float2int64(f)
+	}
 
 	// trim the decimal part
 	if i := strings.Index(s, "."); i >= 0 {
@@ -519,10 +563,13 @@ func str2int64(s string) (i int64, err error) {
 //	"12.01" => 12
 func str2uint64(s string) (i uint64, err error) {
 	// ensure can be converted to float
-	_, err = strconv.ParseFloat(s, 64)
+	f, err := strconv.ParseFloat(s, 64)
 	if err != nil {
 		return
 	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return float2uint64(f)
+	}
 
 	// trim the decimal part
 	if i := strings.Index(s, "."); i >= 0 {
@@ -531,3 +578,29 @@ func str2uint64(s string) (i uint64, err error) {
 	i, err = strconv.ParseUint(s, 10, 64)
 	return
 }
+
+// truncate the float to int64, NaN and ±Inf are handled by NonFinite
+func float2int64(f float64) (int64, error) {
+	f, err := checkNonFinite(f)
+	if err != nil {
+		return 0, err
+	}
+	// 2^63 is not representable in int64
+	if math.IsNaN(f) || f >= math.MaxInt64 || f < math.MinInt64 {
+		return 0, errConvFail
+	}
+	return int64(math.Trunc(f)), nil
+}
+
+// truncate the float to uint64, NaN and ±Inf are handled by NonFinite
+func float2uint64(f float64) (uint64, error) {
+	f, err := checkNonFinite(f)
+	if err != nil {
+		return 0, err
+	}
+	// 2^64 is not representable in uint64
+	if math.IsNaN(f) || f >= math.MaxUint64 || f < 0 {
+		return 0, errConvFail
+	}
+	return uint64(math.Trunc(f)), nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/shockerli/cvt"
 )
@@ -1202,8 +1203,14 @@ func TestUint64E(t *testing.T) {
 		{json.Number("1"), 1, false},
 		{pointerInterNil, 0, false},
 		{&pointerInterNil, 0, false},
+		{time1, 1234567890, false},
+		{&time1, 1234567890, false},
+		{time.Duration(8), 8, false},
 
 		// errors
+		{time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC), 0, true},
+		{&time.Time{}, 0, true},
+		{-time.Second, 0, true},
 		{int(-8), 0, true},
 		{int8(-8), 0, true},
 		{int16(-8), 0, true},
@@ -1819,6 +1826,11 @@ func TestInt64E(t *testing.T) {
 		{&pointerIntNil, 0, false},
 		{(*AliasTypeInt)(nil), 0, false},
 		{(*PointerTypeInt)(nil), 0, false},
+		{time1, 1234567890, false},
+		{&time1, 1234567890, false},
+		{time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC), -1, false},
+		{time.Duration(8), 8, false},
+		{-time.Second, -1e9, false},
 
 		// errors
 		{"10a", 0, true},
@@ -2355,6 +2367,67 @@ func TestIntE(t *testing.T) {
 	}
 }
 
+func TestInt64E_TimestampUnit(t *testing.T) {
+	defer func(unit, durUnit time.Duration) {
+		cvt.TimestampUnit, cvt.DurationUnit = unit, durUnit
+	}(cvt.TimestampUnit, cvt.DurationUnit)
+
+	var tm = time1.Add(123456789)
+	var dur = 90 * time.Second
+	var pdur = &dur
+	tests := []struct {
+		unit    time.Duration
+		input   interface{}
+		expect  int64
+		expectU uint64
+	}{
+		{time.Second, tm, 1234567890, 1234567890},
+		{time.Millisecond, tm, 1234567890123, 1234567890123},
+		{time.Microsecond, tm, 1234567890123456, 1234567890123456},
+		{time.Nanosecond, tm, 1234567890123456789, 1234567890123456789},
+		{time.Minute, tm, 20576131, 20576131},
+		{time.Millisecond, 90 * time.Second, 90000, 90000},
+		{time.Second, 90 * time.Second, 90, 90},
+		{time.Minute, 90 * time.Second, 1, 1},
+		{time.Second, &dur, 90, 90},
+		{time.Millisecond, &pdur, 90000, 90000},
+
+		// the unit <= 0 is the default
+		{0, tm, 1234567890, 1234567890},
+		{-time.Second, 90 * time.Second, int64(90 * time.Second), uint64(90 * time.Second)},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, unit[%s], input[%+v], expect[%v]", i, tt.unit, tt.input, tt.expect)
+
+		cvt.TimestampUnit, cvt.DurationUnit = tt.unit, tt.unit
+		v, err := cvt.Int64E(tt.input)
+		assertNoError(t, err, "[NoErr] "+msg)
+		assertEqual(t, tt.expect, v, "[WithE] "+msg)
+
+		u, err := cvt.Uint64E(tt.input)
+		assertNoError(t, err, "[NoErr] "+msg)
+		assertEqual(t, tt.expectU, u, "[WithE] "+msg)
+	}
+
+	// negative duration to uint
+	cvt.DurationUnit = time.Second
+	neg := -dur
+	_, err := cvt.Uint64E(&neg)
+	assertError(t, err)
+
+	// out of the range of int64
+	cvt.TimestampUnit = time.Nanosecond
+	for _, input := range []interface{}{time.Time{}, time.Date(2300, 1, 1, 0, 0, 0, 0, time.UTC)} {
+		_, err = cvt.Int64E(input)
+		assertError(t, err, input)
+		_, err = cvt.Uint64E(input)
+		assertError(t, err, input)
+		_, err = cvt.BigIntE(input)
+		assertError(t, err, input)
+	}
+}
+
 func BenchmarkToUint(b *testing.B) {
 	values := []interface{}{120, int64(122), "123", "120.0", "120.", []byte("125."), true, false}
 	for n := 0; n < b.N; n++ {
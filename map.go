@@ -1,7 +1,11 @@
 package cvt
 
 import (
+	"bytes"
+	"encoding"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"reflect"
 )
 
@@ -52,6 +56,11 @@ func IntMapE(val interface{}) (m map[int]interface{}, err error) {
 // * Support any `map` type
 // * Support any `struct` type
 func StringMapE(val interface{}) (m map[string]interface{}, err error) {
+	return DefaultFloatFormat.StringMapE(val)
+}
+
+// StringMapE convert an interface to `map[string]interface{}`, the float key and the value of `string` tag option are formatted by ff
+func (ff FloatFormat) StringMapE(val interface{}) (m map[string]interface{}, err error) {
 	m = make(map[string]interface{})
 	if val == nil {
 		return nil, errUnsupportedTypeNil
@@ -74,10 +83,10 @@ func StringMapE(val interface{}) (m map[string]interface{}, err error) {
 	switch rv.Kind() {
 	case reflect.Map:
 		for _, key := range rv.MapKeys() {
-			m[String(key.Interface())] = rv.MapIndex(key).Interface()
+			m[ff.String(key.Interface())] = rv.MapIndex(key).Interface()
 		}
 	case reflect.Struct:
-		m = struct2map(rv)
+		m = struct2map(rv, ff)
 	case reflect.Slice:
 		// []byte, JSON
 		// Example: []byte(`{"name":"bob","age":18}`)
@@ -93,7 +102,8 @@ func StringMapE(val interface{}) (m map[string]interface{}, err error) {
 	return
 }
 
-func struct2map(rv reflect.Value) map[string]interface{} {
+// the value of `string` tag option is formatted by ff
+func struct2map(rv reflect.Value, ff FloatFormat) map[string]interface{} {
 	var m = make(map[string]interface{})
 	if !rv.IsValid() {
 		return m
@@ -101,18 +111,510 @@ func struct2map(rv reflect.Value) map[string]interface{} {
 
 	for j := 0; j < rv.NumField(); j++ {
 		f := rv.Type().Field(j)
-		t := ptrType(f.Type)
+		tag := parseFieldTag(f)
+		if tag.omit || (tag.omitEmpty && isEmptyValue(rv.Field(j))) {
+			continue
+		}
+
 		vv := ptrValue(rv.Field(j))
-		if f.Anonymous && t.Kind() == reflect.Struct {
-			for k, v := range struct2map(vv) {
+		if tag.flatten(f) {
+			for k, v := range struct2map(vv, ff) {
 				// anonymous subfield has a low priority
 				if _, ok := m[k]; !ok {
 					m[k] = v
 				}
 			}
 		} else if vv.IsValid() && vv.CanInterface() {
-			m[f.Name] = vv.Interface()
+			switch k := vv.Kind(); {
+			case tag.asString && (k >= reflect.Bool && k <= reflect.Float64 || k == reflect.String):
+				m[tag.key(f)] = ff.String(vv.Interface())
+			default:
+				m[tag.key(f)] = vv.Interface()
+			}
 		}
 	}
 	return m
 }
+
+// StringMapString convert an interface to `map[string]string`, with default value
+func StringMapString(v interface{}, def ...map[string]string) map[string]string {
+	if v, err := StringMapStringE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// StringMapStringE convert an interface to `map[string]string`, the keys and values are converted by the scalar or slice converters
+func StringMapStringE(val interface{}) (m map[string]string, err error) {
+	err = convMapTo(val, &m)
+	return
+}
+
+// StringMapStringE convert an interface to `map[string]string`, the float keys and values are formatted by ff
+func (ff FloatFormat) StringMapStringE(val interface{}) (m map[string]string, err error) {
+	c := defaultConverter()
+	c.FloatFormat = ff
+	err = c.convMapTo(val, &m)
+	return
+}
+
+// StringMapInt convert an interface to `map[string]int`, with default value
+func StringMapInt(v interface{}, def ...map[string]int) map[string]int {
+	if v, err := StringMapIntE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// StringMapIntE convert an interface to `map[string]int`, the keys and values are converted by the scalar or slice converters
+func StringMapIntE(val interface{}) (m map[string]int, err error) {
+	err = convMapTo(val, &m)
+	return
+}
+
+// StringMapInt64 convert an interface to `map[string]int64`, with default value
+func StringMapInt64(v interface{}, def ...map[string]int64) map[string]int64 {
+	if v, err := StringMapInt64E(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// StringMapInt64E convert an interface to `map[string]int64`, the keys and values are converted by the scalar or slice converters
+func StringMapInt64E(val interface{}) (m map[string]int64, err error) {
+	err = convMapTo(val, &m)
+	return
+}
+
+// StringMapFloat64 convert an interface to `map[string]float64`, with default value
+func StringMapFloat64(v interface{}, def ...map[string]float64) map[string]float64 {
+	if v, err := StringMapFloat64E(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// StringMapFloat64E convert an interface to `map[string]float64`, the keys and values are converted by the scalar or slice converters
+func StringMapFloat64E(val interface{}) (m map[string]float64, err error) {
+	err = convMapTo(val, &m)
+	return
+}
+
+// StringMapBool convert an interface to `map[string]bool`, with default value
+func StringMapBool(v interface{}, def ...map[string]bool) map[string]bool {
+	if v, err := StringMapBoolE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// StringMapBoolE convert an interface to `map[string]bool`, the keys and values are converted by the scalar or slice converters
+func StringMapBoolE(val interface{}) (m map[string]bool, err error) {
+	err = convMapTo(val, &m)
+	return
+}
+
+// StringMapSlice convert an interface to `map[string][]interface{}`, with default value
+func StringMapSlice(v interface{}, def ...map[string][]interface{}) map[string][]interface{} {
+	if v, err := StringMapSliceE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// StringMapSliceE convert an interface to `map[string][]interface{}`, the keys and values are converted by the scalar or slice converters
+func StringMapSliceE(val interface{}) (m map[string][]interface{}, err error) {
+	err = convMapTo(val, &m)
+	return
+}
+
+// StringMapStringSlice convert an interface to `map[string][]string`, with default value
+func StringMapStringSlice(v interface{}, def ...map[string][]string) map[string][]string {
+	if v, err := StringMapStringSliceE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// StringMapStringSliceE convert an interface to `map[string][]string`, the keys and values are converted by the scalar or slice converters
+func StringMapStringSliceE(val interface{}) (m map[string][]string, err error) {
+	err = convMapTo(val, &m)
+	return
+}
+
+// MapToE convert an interface to the map that ptr points to, the keys and values are converted by the converter of their types
+// * the ptr must be a non-nil pointer of map, such as *map[string]int, *map[int][]string
+// * the key collision after converting returns an error, eg: {"1": 1, "01": 2} to map[int]int
+// * the error reports the key of entry, by the mode of CollectSliceErrors
+//
+//	var m map[string]int
+//	cvt.MapToE(`{"a": "1", "b": 2.5}`, &m) // map[string]int{"a": 1, "b": 2}
+func MapToE(val interface{}, ptr interface{}) error {
+	return defaultConverter().MapToE(val, ptr)
+}
+
+// MapToE convert an interface to the map that ptr points to, by the options of c
+func (c Converter) MapToE(val interface{}, ptr interface{}) error {
+	pv := reflect.ValueOf(ptr)
+	if ptr == nil || pv.Kind() != reflect.Ptr || pv.IsNil() || pv.Elem().Kind() != reflect.Map {
+		return fmt.Errorf("%w, the ptr must be a non-nil pointer of map, got %T", errConvFail, ptr)
+	}
+	return c.convMapTo(val, ptr)
+}
+
+var errKeyCollision = errors.New("key collision")
+
+// convert any value to the map, and store in the pointer of map
+func convMapTo(val interface{}, ptr interface{}) error {
+	return defaultConverter().convMapTo(val, ptr)
+}
+
+func (c Converter) convMapTo(val interface{}, ptr interface{}) error {
+	pv := reflect.ValueOf(ptr).Elem()
+	m, err := c.convMap(val, pv.Type())
+	if m.IsValid() {
+		pv.Set(m)
+	}
+	return err
+}
+
+// convert any value to the map of type t, the keys and values are converted by convValue
+// the error reports the key of entry, by the mode of c.CollectErrors
+func (c Converter) convMap(val interface{}, t reflect.Type) (reflect.Value, error) {
+	keys, values, err := mapEntries(val, c.FloatFormat)
+	if e := catch(t.String(), val, err); e != nil {
+		return reflect.Value{}, e
+	}
+
+	m := reflect.MakeMapWithSize(t, len(keys))
+	origin := make(map[interface{}]interface{}, len(keys))
+	var errs ElementErrors
+	for j, k := range keys {
+		kv, err := c.convValue(k, t.Key())
+		if err == nil {
+			if prev, ok := origin[kv.Interface()]; ok {
+				err = fmt.Errorf("%w with key %#v", errKeyCollision, prev)
+			} else {
+				origin[kv.Interface()] = k
+			}
+		}
+
+		var vv reflect.Value
+		if err == nil {
+			vv, err = c.convValue(values[j], t.Elem())
+		}
+
+		if err != nil {
+			e := &ElementError{Index: j, Key: k, Err: err}
+			if !c.CollectErrors {
+				return reflect.Value{}, e
+			}
+			errs = append(errs, e)
+			continue
+		}
+		m.SetMapIndex(kv, vv)
+	}
+	if len(errs) > 0 {
+		return m, errs
+	}
+
+	return m, nil
+}
+
+// returns the keys and values of map, struct or JSON object, the keys are sorted by asc
+// the value of `string` tag option is formatted by ff
+func mapEntries(val interface{}, ff FloatFormat) (keys, values []interface{}, err error) {
+	if val == nil {
+		return nil, nil, errUnsupportedTypeNil
+	}
+
+	_, rv := Indirect(val)
+	switch rv.Kind() {
+	case reflect.Map:
+	case reflect.Struct:
+		rv = reflect.ValueOf(struct2map(rv, ff))
+	case reflect.String, reflect.Slice:
+		// JSON object, the number is decoded as json.Number
+		// Example: `{"name":"bob","age":18}`
+		var text []byte
+		if rv.Kind() == reflect.String {
+			text = []byte(rv.String())
+		} else if rv.Type().Elem().Kind() == reflect.Uint8 {
+			text = rv.Bytes()
+		} else {
+			return nil, nil, errConvFail
+		}
+		var m map[string]interface{}
+		d := json.NewDecoder(bytes.NewReader(text))
+		d.UseNumber()
+		if err = d.Decode(&m); err != nil {
+			return nil, nil, err
+		}
+		rv = reflect.ValueOf(m)
+	default:
+		return nil, nil, errConvFail
+	}
+
+	for _, key := range sortedMapKeys(rv) {
+		keys = append(keys, key.Interface())
+		values = append(values, rv.MapIndex(key).Interface())
+	}
+	return
+}
+
+// DeepMaxDepth the max depth of nested struct, map and slice in StringMapDeepE, default 32
+var DeepMaxDepth = 32
+
+var errCycle = errors.New("cycle detected")
+var errMaxDepth = errors.New("max depth exceeded")
+
+// StringMapDeep convert an interface to `map[string]interface{}` deeply, with default value
+func StringMapDeep(v interface{}, def ...map[string]interface{}) map[string]interface{} {
+	if v, err := StringMapDeepE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// StringMapDeepE convert an interface to `map[string]interface{}` deeply,
+// the nested struct, map, slice and array are converted to `map[string]interface{}` and `[]interface{}`
+// * the struct field is named by StructTag, same as StringMapE
+// * the pointer and interface are dereferenced, nil for the nil value
+// * the type implements json.Marshaler or encoding.TextMarshaler is kept, such as time.Time
+// * the struct without exported field is kept, such as big.Int
+// * returns an error on the cyclic reference, or the depth is over DeepMaxDepth
+func StringMapDeepE(val interface{}) (map[string]interface{}, error) {
+	if val == nil {
+		return nil, errUnsupportedTypeNil
+	}
+
+	// JSON is a tree already
+	if _, rv := Indirect(val); rv.Kind() == reflect.String || rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+		m, err := StringMapE(val)
+		if e := catch("map[string]interface{}", val, err); e != nil {
+			return nil, e
+		}
+		return m, nil
+	}
+
+	w := deepWalker{visiting: make(map[deepVisit]bool)}
+	v, err := w.walk(reflect.ValueOf(val), "", 0)
+	if err != nil {
+		// not format the value, it may be cyclic
+		return nil, fmt.Errorf("unable to convert value of type %T to map[string]interface{}, %w", val, err)
+	}
+	if m, ok := v.(map[string]interface{}); ok {
+		return m, nil
+	}
+
+	return nil, newErr(val, "map[string]interface{}")
+}
+
+// the reference on the path of walking
+type deepVisit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// walk the value deeply, and detect the cyclic reference
+type deepWalker struct {
+	visiting map[deepVisit]bool
+}
+
+var (
+	typeJSONMarshaler = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	typeTextMarshaler = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// the value is kept as is, not converted deeply
+func isDeepLeaf(rv reflect.Value) bool {
+	t := rv.Type()
+	if t.Implements(typeJSONMarshaler) || t.Implements(typeTextMarshaler) {
+		return true
+	}
+	switch rv.Kind() {
+	case reflect.Struct:
+		for j := 0; j < t.NumField(); j++ {
+			if t.Field(j).PkgPath == "" {
+				return false
+			}
+		}
+		return true
+	case reflect.Slice:
+		// []byte
+		return t.Elem().Kind() == reflect.Uint8
+	}
+	return false
+}
+
+// enter the reference, returns false if it is on the path
+func (w *deepWalker) enter(rv reflect.Value) bool {
+	k := deepVisit{rv.Pointer(), rv.Type()}
+	if w.visiting[k] {
+		return false
+	}
+	w.visiting[k] = true
+	return true
+}
+
+func (w *deepWalker) leave(rv reflect.Value) {
+	delete(w.visiting, deepVisit{rv.Pointer(), rv.Type()})
+}
+
+func (w *deepWalker) walk(rv reflect.Value, path string, depth int) (interface{}, error) {
+	if !rv.IsValid() {
+		return nil, nil
+	}
+	if depth > DeepMaxDepth {
+		return nil, fmt.Errorf("%w(%d) at %s", errMaxDepth, DeepMaxDepth, path)
+	}
+
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		if rv.IsNil() {
+			return nil, nil
+		}
+	}
+	if isDeepLeaf(rv) {
+		return rv.Interface(), nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Interface:
+		return w.walk(rv.Elem(), path, depth)
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if !w.enter(rv) {
+			return nil, fmt.Errorf("%w at %s", errCycle, path)
+		}
+		defer w.leave(rv)
+	}
+
+	switch rv.Kind() {
+	case reflect.Ptr:
+		return w.walk(rv.Elem(), path, depth)
+	case reflect.Map:
+		m := make(map[string]interface{}, rv.Len())
+		for _, key := range sortedMapKeys(rv) {
+			k := String(key.Interface())
+			v, err := w.walk(rv.MapIndex(key), path+"."+k, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = v
+		}
+		return m, nil
+	case reflect.Slice, reflect.Array:
+		sl := make([]interface{}, rv.Len())
+		for j := range sl {
+			v, err := w.walk(rv.Index(j), fmt.Sprintf("%s[%d]", path, j), depth+1)
+			if err != nil {
+				return nil, err
+			}
+			sl[j] = v
+		}
+		return sl, nil
+	case reflect.Struct:
+		m := make(map[string]interface{})
+		if err := w.walkStruct(rv, m, path, depth); err != nil {
+			return nil, err
+		}
+		return m, nil
+	}
+
+	return rv.Interface(), nil
+}
+
+// walk the fields of struct, same as struct2map
+func (w *deepWalker) walkStruct(rv reflect.Value, m map[string]interface{}, path string, depth int) error {
+	for j := 0; j < rv.NumField(); j++ {
+		f := rv.Type().Field(j)
+		tag := parseFieldTag(f)
+		// the exported fields of unexported embedded struct are kept, same as struct2map
+		if (f.PkgPath != "" && !tag.flatten(f)) || tag.omit || (tag.omitEmpty && isEmptyValue(rv.Field(j))) {
+			continue
+		}
+
+		if tag.flatten(f) {
+			sub, err := w.walkEmbedded(rv.Field(j), path, depth)
+			if err != nil {
+				return err
+			}
+			for k, v := range sub {
+				// anonymous subfield has a low priority
+				if _, ok := m[k]; !ok {
+					m[k] = v
+				}
+			}
+			continue
+		}
+
+		k := tag.key(f)
+		if kind := ptrValue(rv.Field(j)).Kind(); tag.asString && (kind >= reflect.Bool && kind <= reflect.Float64 || kind == reflect.String) {
+			m[k] = String(rv.Field(j).Interface())
+			continue
+		}
+		v, err := w.walk(rv.Field(j), path+"."+k, depth+1)
+		if err != nil {
+			return err
+		}
+		m[k] = v
+	}
+	return nil
+}
+
+// walk the fields of embedded struct, the embedded pointer is checked for the cyclic reference
+func (w *deepWalker) walkEmbedded(rv reflect.Value, path string, depth int) (map[string]interface{}, error) {
+	sub := make(map[string]interface{})
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return sub, nil
+		}
+		if !w.enter(rv) {
+			return nil, fmt.Errorf("%w at %s", errCycle, path)
+		}
+		defer w.leave(rv)
+		rv = rv.Elem()
+	}
+
+	return sub, w.walkStruct(rv, sub, path, depth)
+}
@@ -1,8 +1,14 @@
 package cvt_test
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/shockerli/cvt"
 )
@@ -134,3 +140,332 @@ func TestIntMapE(t *testing.T) {
 		assertEqual(t, tt.expect, v, "[WithE] "+msg)
 	}
 }
+
+func TestStringMapTypedE(t *testing.T) {
+	conv := map[string]func(interface{}) (interface{}, error){
+		"string":      func(v interface{}) (interface{}, error) { return cvt.StringMapStringE(v) },
+		"int":         func(v interface{}) (interface{}, error) { return cvt.StringMapIntE(v) },
+		"int64":       func(v interface{}) (interface{}, error) { return cvt.StringMapInt64E(v) },
+		"float64":     func(v interface{}) (interface{}, error) { return cvt.StringMapFloat64E(v) },
+		"bool":        func(v interface{}) (interface{}, error) { return cvt.StringMapBoolE(v) },
+		"slice":       func(v interface{}) (interface{}, error) { return cvt.StringMapSliceE(v) },
+		"stringSlice": func(v interface{}) (interface{}, error) { return cvt.StringMapStringSliceE(v) },
+	}
+
+	tests := []struct {
+		to     string
+		input  interface{}
+		expect interface{}
+		isErr  bool
+	}{
+		{"string", map[int]interface{}{1: 1.5, 2: true, 3: nil}, map[string]string{"1": "1.5", "2": "true", "3": ""}, false},
+		{"string", `{"a": 1, "b": "x"}`, map[string]string{"a": "1", "b": "x"}, false},
+		{"string", TestStructB{TestStructC{"c"}, 1}, map[string]string{"B1": "1", "C1": "c"}, false},
+		{"string", map[string]string{}, map[string]string{}, false},
+		{"int", map[string]string{"a": "1", "b": "2.5"}, map[string]int{"a": 1, "b": 2}, false},
+		{"int", []byte(`{"a": 1}`), map[string]int{"a": 1}, false},
+		{"int64", `{"big": 9007199254740993}`, map[string]int64{"big": 9007199254740993}, false},
+		{"float64", map[interface{}]interface{}{1: "1.5", "x": 2}, map[string]float64{"1": 1.5, "x": 2}, false},
+		{"bool", map[string]interface{}{"a": "yes", "b": 0}, map[string]bool{"a": true, "b": false}, false},
+		{"slice", `{"a": [1, "x"], "b": "hi"}`, map[string][]interface{}{"a": {json.Number("1"), "x"}, "b": {'h', 'i'}}, false},
+		{"stringSlice", map[string]interface{}{"a": []int{1, 2}, "b": `["x"]`}, map[string][]string{"a": {"1", "2"}, "b": {"x"}}, false},
+
+		// errors
+		{"string", nil, nil, true},
+		{"string", 123, nil, true},
+		{"string", "hello", nil, true},
+		{"int", map[string]string{"a": "x"}, nil, true},
+		{"bool", map[string]string{"a": "hello"}, nil, true},
+		{"stringSlice", map[string]interface{}{"a": 1}, nil, true},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, to[%s], input[%+v], expect[%+v], isErr[%v]", i, tt.to, tt.input, tt.expect, tt.isErr)
+
+		v, err := conv[tt.to](tt.input)
+		if tt.isErr {
+			assertError(t, err, "[HasErr] "+msg)
+			continue
+		}
+
+		assertNoError(t, err, "[NoErr] "+msg)
+		assertEqual(t, tt.expect, v, "[WithE] "+msg)
+	}
+}
+
+func TestStringMapTypedE_ElementError(t *testing.T) {
+	defer func(collect bool) {
+		cvt.CollectSliceErrors = collect
+	}(cvt.CollectSliceErrors)
+
+	// per-key error
+	_, err := cvt.StringMapIntE(map[string]string{"a": "1", "b": "x"})
+	var e *cvt.ElementError
+	assertEqual(t, true, errors.As(err, &e))
+	assertEqual(t, "b", e.Key)
+	assertEqual(t, `key "b": unable to convert "x" of type string to int, strconv.ParseFloat: parsing "x": invalid syntax`, err.Error())
+
+	// key collision
+	_, err = cvt.StringMapIntE(map[interface{}]int{1: 1, "1": 2})
+	assertEqual(t, true, errors.As(err, &e))
+	assertEqual(t, `key "1": key collision with key 1`, err.Error())
+
+	// collect all
+	cvt.CollectSliceErrors = true
+	m, err := cvt.StringMapIntE(map[string]string{"a": "x", "b": "2", "c": "y"})
+	var es cvt.ElementErrors
+	assertEqual(t, true, errors.As(err, &es))
+	assertEqual(t, 2, len(es))
+	assertEqual(t, "a", es[0].Key)
+	assertEqual(t, "c", es[1].Key)
+	assertEqual(t, map[string]int{"b": 2}, m)
+}
+
+func TestStringMapTyped_HasDefault(t *testing.T) {
+	assertEqual(t, map[string]string{"a": "1"}, cvt.StringMapString(map[string]int{"a": 1}))
+	assertEqual(t, map[string]string{"x": "x"}, cvt.StringMapString(1, map[string]string{"x": "x"}))
+	assertEqual(t, map[string]int{"x": 1}, cvt.StringMapInt(1, map[string]int{"x": 1}))
+	assertEqual(t, map[string]int64{"x": 1}, cvt.StringMapInt64(1, map[string]int64{"x": 1}))
+	assertEqual(t, map[string]float64{"x": 1}, cvt.StringMapFloat64(1, map[string]float64{"x": 1}))
+	assertEqual(t, map[string]bool{"x": true}, cvt.StringMapBool(1, map[string]bool{"x": true}))
+	assertEqual(t, map[string][]interface{}{"x": nil}, cvt.StringMapSlice(1, map[string][]interface{}{"x": nil}))
+	assertEqual(t, map[string][]string{"x": nil}, cvt.StringMapStringSlice(1, map[string][]string{"x": nil}))
+	assertEqual(t, map[string]int(nil), cvt.StringMapInt(nil))
+}
+
+func TestStringMapE_StructTag(t *testing.T) {
+	defer func(tag string) {
+		cvt.StructTag = tag
+	}(cvt.StructTag)
+
+	input := TestStructTag{ID: 1, Password: "x", Age: 18, Note: "n", TestStructTagEmbed: TestStructTagEmbed{Level: 3}}
+	tests := []struct {
+		tag    string
+		input  interface{}
+		expect map[string]interface{}
+	}{
+		{"json", input, map[string]interface{}{
+			"id": 1, "age": "18", "Note": "n", "level": 3, "meta": TestStructD{},
+		}},
+		{"json", &TestStructTag{Name: "bob"}, map[string]interface{}{
+			"id": 0, "name": "bob", "age": "0", "Note": "", "level": 0, "meta": TestStructD{},
+		}},
+		{"cvt", input, map[string]interface{}{
+			"uid": 1, "Name": "", "Password": "x", "Age": 18, "Note": "n", "Level": 3, "Meta": TestStructD{},
+		}},
+		{"", input, map[string]interface{}{
+			"ID": 1, "Name": "", "Password": "x", "Age": 18, "Note": "n", "Level": 3, "Meta": TestStructD{},
+		}},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, tag[%s], input[%+v], expect[%+v]", i, tt.tag, tt.input, tt.expect)
+
+		cvt.StructTag = tt.tag
+		v, err := cvt.StringMapE(tt.input)
+		assertNoError(t, err, "[NoErr] "+msg)
+		assertEqual(t, tt.expect, v, "[WithE] "+msg)
+	}
+}
+
+type TestStructDeep struct {
+	Name     string           `json:"name"`
+	Created  time.Time        `json:"created"`
+	Child    *TestStructDeep  `json:"child,omitempty"`
+	Children []TestStructDeep `json:"children,omitempty"`
+	Tags     map[string]TestStructD
+	Amount   *big.Int
+	private  int
+	TestStructTagEmbed
+}
+
+type TestStructCycle struct {
+	Name string
+	Next *TestStructCycle
+	List []interface{}
+}
+
+type TestStructSelfEmbed struct {
+	*TestStructSelfEmbed
+	Name string
+}
+
+type testStructInner struct {
+	X int
+	y int
+}
+
+type TestStructOuter struct {
+	testStructInner
+	*TestStructMatchB
+	Y int
+}
+
+func TestStringMapDeepE_UnexportedEmbed(t *testing.T) {
+	input := TestStructOuter{testStructInner{1, 2}, &TestStructMatchB{"b"}, 3}
+	expect := map[string]interface{}{"X": 1, "Same": "b", "Y": 3}
+
+	v, err := cvt.StringMapE(input)
+	assertNoError(t, err)
+	assertEqual(t, expect, v)
+
+	v, err = cvt.StringMapDeepE(input)
+	assertNoError(t, err)
+	assertEqual(t, expect, v)
+}
+
+func TestStringMapDeepE(t *testing.T) {
+	shared := &TestStructD{D1: 1}
+	tests := []struct {
+		input  interface{}
+		expect map[string]interface{}
+		isErr  bool
+	}{
+		{TestStructDeep{
+			Name:               "a",
+			Created:            time1,
+			Child:              &TestStructDeep{Name: "b"},
+			Children:           []TestStructDeep{{Name: "c"}},
+			Tags:               map[string]TestStructD{"x": {D1: 1}},
+			Amount:             big.NewInt(10),
+			private:            1,
+			TestStructTagEmbed: TestStructTagEmbed{Level: 2},
+		}, map[string]interface{}{
+			"name":    "a",
+			"created": time1,
+			"child": map[string]interface{}{
+				"name": "b", "created": time.Time{}, "Tags": nil, "Amount": nil, "level": 0,
+			},
+			"children": []interface{}{map[string]interface{}{
+				"name": "c", "created": time.Time{}, "Tags": nil, "Amount": nil, "level": 0,
+			}},
+			"Tags":   map[string]interface{}{"x": map[string]interface{}{"D1": 1}},
+			"Amount": big.NewInt(10),
+			"level":  2,
+		}, false},
+		{&map[int]interface{}{1: []*TestStructD{shared, shared, nil}, 2: [1]interface{}{shared}}, map[string]interface{}{
+			"1": []interface{}{map[string]interface{}{"D1": 1}, map[string]interface{}{"D1": 1}, nil},
+			"2": []interface{}{map[string]interface{}{"D1": 1}},
+		}, false},
+		{map[string]interface{}{"b": []byte("hi"), "j": json.RawMessage(`1`)}, map[string]interface{}{
+			"b": []byte("hi"), "j": json.RawMessage(`1`),
+		}, false},
+		{`{"a": {"b": 1}}`, map[string]interface{}{"a": map[string]interface{}{"b": float64(1)}}, false},
+
+		// errors
+		{nil, nil, true},
+		{123, nil, true},
+		{[]int{1}, nil, true},
+		{"hello", nil, true},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, input[%+v], expect[%+v], isErr[%v]", i, tt.input, tt.expect, tt.isErr)
+
+		v, err := cvt.StringMapDeepE(tt.input)
+		if tt.isErr {
+			assertError(t, err, "[HasErr] "+msg)
+			continue
+		}
+
+		assertNoError(t, err, "[NoErr] "+msg)
+		assertEqual(t, tt.expect, v, "[WithE] "+msg)
+
+		// Non-E test
+		v = cvt.StringMapDeep(tt.input)
+		assertEqual(t, tt.expect, v, "[NonE] "+msg)
+	}
+}
+
+func TestStringMapDeepE_Cycle(t *testing.T) {
+	defer func(depth int) {
+		cvt.DeepMaxDepth = depth
+	}(cvt.DeepMaxDepth)
+
+	// pointer cycle
+	a := &TestStructCycle{Name: "a"}
+	a.Next = &TestStructCycle{Name: "b", Next: a}
+	_, err := cvt.StringMapDeepE(a)
+	assertError(t, err)
+	assertEqual(t, true, strings.Contains(err.Error(), "cycle detected at .Next.Next"), err.Error())
+
+	// slice cycle
+	b := &TestStructCycle{Name: "b", List: make([]interface{}, 1)}
+	b.List[0] = b.List
+	_, err = cvt.StringMapDeepE(b)
+	assertEqual(t, true, strings.Contains(err.Error(), "cycle detected at .List[0]"), err.Error())
+
+	// map cycle
+	m := map[string]interface{}{}
+	m["self"] = m
+	_, err = cvt.StringMapDeepE(m)
+	assertEqual(t, true, strings.Contains(err.Error(), "cycle detected at .self"), err.Error())
+
+	// embedded pointer cycle
+	s := TestStructSelfEmbed{Name: "s"}
+	s.TestStructSelfEmbed = &s
+	_, err = cvt.StringMapDeepE(&s)
+	assertEqual(t, true, strings.Contains(err.Error(), "cycle detected"), err.Error())
+	_, err = cvt.StringMapDeepE(s)
+	assertEqual(t, true, strings.Contains(err.Error(), "cycle detected"), err.Error())
+
+	// depth limit
+	cvt.DeepMaxDepth = 2
+	_, err = cvt.StringMapDeepE(map[string]interface{}{"a": map[string]interface{}{"b": map[string]interface{}{}}})
+	assertNoError(t, err)
+	_, err = cvt.StringMapDeepE(map[string]interface{}{"a": map[string]interface{}{"b": map[string]interface{}{"c": 1}}})
+	assertEqual(t, true, strings.Contains(err.Error(), "max depth exceeded(2) at .a.b.c"), err.Error())
+
+	assertEqual(t, map[string]interface{}{"x": 1}, cvt.StringMapDeep(a, map[string]interface{}{"x": 1}))
+}
+
+func TestMapToE(t *testing.T) {
+	type Key string
+	type Level int
+
+	tests := []struct {
+		input  interface{}
+		ptr    interface{}
+		expect interface{}
+		isErr  bool
+	}{
+		{`{"a": "1", "b": 2.5}`, new(map[string]int), map[string]int{"a": 1, "b": 2}, false},
+		{map[string]interface{}{"1": 1, "2": true}, new(map[int]string), map[int]string{1: "1", 2: "true"}, false},
+		{map[string]string{"a": "1"}, new(map[Key]Level), map[Key]Level{"a": 1}, false},
+		{map[string]string{"a": "1h"}, new(map[string]time.Duration), map[string]time.Duration{"a": time.Hour}, false},
+		{`{"a": [1, "2"], "b": []}`, new(map[string][]int), map[string][]int{"a": {1, 2}, "b": nil}, false},
+		{`{"a": {"x": "yes"}}`, new(map[string]map[string]bool), map[string]map[string]bool{"a": {"x": true}}, false},
+		{map[string]interface{}{"a": "1", "b": nil}, new(map[string]*int), map[string]*int{"a": cvt.IntP(1), "b": nil}, false},
+		{map[int]int{1: 1}, new(map[string]interface{}), map[string]interface{}{"1": 1}, false},
+
+		// errors
+		{`{"a": [1, "2"], "b": true}`, new(map[string][]int), nil, true},
+		{map[string]int{"1": 1, "01": 2}, new(map[int]int), nil, true},
+		{map[string]int{"a": 1}, new(map[int]int), nil, true},
+		{map[string]int{"a": 1}, new(map[string]struct{}), nil, true},
+		{[]int{1}, new(map[string]int), nil, true},
+		{map[string]int{"a": 1}, nil, nil, true},
+		{map[string]int{"a": 1}, map[string]int{}, nil, true},
+		{map[string]int{"a": 1}, (*map[string]int)(nil), nil, true},
+		{map[string]int{"a": 1}, new(int), nil, true},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, input[%+v], expect[%+v], isErr[%v]", i, tt.input, tt.expect, tt.isErr)
+
+		err := cvt.MapToE(tt.input, tt.ptr)
+		if tt.isErr {
+			assertError(t, err, "[HasErr] "+msg)
+			continue
+		}
+
+		assertNoError(t, err, "[NoErr] "+msg)
+		assertEqual(t, tt.expect, reflect.ValueOf(tt.ptr).Elem().Interface(), "[WithE] "+msg)
+	}
+
+	var m map[int]int
+	err := cvt.MapToE(map[string]int{"1": 1, "01": 2}, &m)
+	var e *cvt.ElementError
+	assertEqual(t, true, errors.As(err, &e))
+	assertEqual(t, `key "1": key collision with key "01"`, err.Error())
+}
@@ -0,0 +1,461 @@
+package cvt
+
+import (
+	"strings"
+	"time"
+)
+
+// EmptyStringAsNull whether the empty string is treated as null by the nullable converters, like IntNE
+var EmptyStringAsNull = false
+
+// returns whether the value is null:
+// nil, nil pointer, string "null"(case-insensitive), and empty string if EmptyStringAsNull is true
+func isNull(val interface{}) bool {
+	v, _ := indirectScalar(val)
+	switch vv := v.(type) {
+	case nil:
+		return true
+	case string:
+		return isNullString(vv)
+	case []byte:
+		return isNullString(string(vv))
+	}
+	return false
+}
+
+func isNullString(s string) bool {
+	s = strings.TrimSpace(s)
+	return strings.EqualFold(s, "null") || (s == "" && EmptyStringAsNull)
+}
+
+// BoolN convert an interface to a *bool type, returns nil if the value is null, with default value
+func BoolN(v interface{}, def ...*bool) *bool {
+	if v, err := BoolNE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// BoolNE convert an interface to a *bool type, returns nil if the value is null
+func BoolNE(val interface{}) (*bool, error) {
+	if isNull(val) {
+		return nil, nil
+	}
+
+	v, err := BoolE(val)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+// IntN convert an interface to a *int type, returns nil if the value is null, with default value
+func IntN(v interface{}, def ...*int) *int {
+	if v, err := IntNE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// IntNE convert an interface to a *int type, returns nil if the value is null
+func IntNE(val interface{}) (*int, error) {
+	if isNull(val) {
+		return nil, nil
+	}
+
+	v, err := IntE(val)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+// Int8N convert an interface to a *int8 type, returns nil if the value is null, with default value
+func Int8N(v interface{}, def ...*int8) *int8 {
+	if v, err := Int8NE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// Int8NE convert an interface to a *int8 type, returns nil if the value is null
+func Int8NE(val interface{}) (*int8, error) {
+	if isNull(val) {
+		return nil, nil
+	}
+
+	v, err := Int8E(val)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+// Int16N convert an interface to a *int16 type, returns nil if the value is null, with default value
+func Int16N(v interface{}, def ...*int16) *int16 {
+	if v, err := Int16NE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// Int16NE convert an interface to a *int16 type, returns nil if the value is null
+func Int16NE(val interface{}) (*int16, error) {
+	if isNull(val) {
+		return nil, nil
+	}
+
+	v, err := Int16E(val)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+// Int32N convert an interface to a *int32 type, returns nil if the value is null, with default value
+func Int32N(v interface{}, def ...*int32) *int32 {
+	if v, err := Int32NE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// Int32NE convert an interface to a *int32 type, returns nil if the value is null
+func Int32NE(val interface{}) (*int32, error) {
+	if isNull(val) {
+		return nil, nil
+	}
+
+	v, err := Int32E(val)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+// Int64N convert an interface to a *int64 type, returns nil if the value is null, with default value
+func Int64N(v interface{}, def ...*int64) *int64 {
+	if v, err := Int64NE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// Int64NE convert an interface to a *int64 type, returns nil if the value is null
+func Int64NE(val interface{}) (*int64, error) {
+	if isNull(val) {
+		return nil, nil
+	}
+
+	v, err := Int64E(val)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+// UintN convert an interface to a *uint type, returns nil if the value is null, with default value
+func UintN(v interface{}, def ...*uint) *uint {
+	if v, err := UintNE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// UintNE convert an interface to a *uint type, returns nil if the value is null
+func UintNE(val interface{}) (*uint, error) {
+	if isNull(val) {
+		return nil, nil
+	}
+
+	v, err := UintE(val)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+// Uint8N convert an interface to a *uint8 type, returns nil if the value is null, with default value
+func Uint8N(v interface{}, def ...*uint8) *uint8 {
+	if v, err := Uint8NE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// Uint8NE convert an interface to a *uint8 type, returns nil if the value is null
+func Uint8NE(val interface{}) (*uint8, error) {
+	if isNull(val) {
+		return nil, nil
+	}
+
+	v, err := Uint8E(val)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+// Uint16N convert an interface to a *uint16 type, returns nil if the value is null, with default value
+func Uint16N(v interface{}, def ...*uint16) *uint16 {
+	if v, err := Uint16NE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// Uint16NE convert an interface to a *uint16 type, returns nil if the value is null
+func Uint16NE(val interface{}) (*uint16, error) {
+	if isNull(val) {
+		return nil, nil
+	}
+
+	v, err := Uint16E(val)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+// Uint32N convert an interface to a *uint32 type, returns nil if the value is null, with default value
+func Uint32N(v interface{}, def ...*uint32) *uint32 {
+	if v, err := Uint32NE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// Uint32NE convert an interface to a *uint32 type, returns nil if the value is null
+func Uint32NE(val interface{}) (*uint32, error) {
+	if isNull(val) {
+		return nil, nil
+	}
+
+	v, err := Uint32E(val)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+// Uint64N convert an interface to a *uint64 type, returns nil if the value is null, with default value
+func Uint64N(v interface{}, def ...*uint64) *uint64 {
+	if v, err := Uint64NE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// Uint64NE convert an interface to a *uint64 type, returns nil if the value is null
+func Uint64NE(val interface{}) (*uint64, error) {
+	if isNull(val) {
+		return nil, nil
+	}
+
+	v, err := Uint64E(val)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+// Float32N convert an interface to a *float32 type, returns nil if the value is null, with default value
+func Float32N(v interface{}, def ...*float32) *float32 {
+	if v, err := Float32NE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// Float32NE convert an interface to a *float32 type, returns nil if the value is null
+func Float32NE(val interface{}) (*float32, error) {
+	if isNull(val) {
+		return nil, nil
+	}
+
+	v, err := Float32E(val)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+// Float64N convert an interface to a *float64 type, returns nil if the value is null, with default value
+func Float64N(v interface{}, def ...*float64) *float64 {
+	if v, err := Float64NE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// Float64NE convert an interface to a *float64 type, returns nil if the value is null
+func Float64NE(val interface{}) (*float64, error) {
+	if isNull(val) {
+		return nil, nil
+	}
+
+	v, err := Float64E(val)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+// StringN convert an interface to a *string type, returns nil if the value is null, with default value
+func StringN(v interface{}, def ...*string) *string {
+	if v, err := StringNE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// StringNE convert an interface to a *string type, returns nil if the value is null
+func StringNE(val interface{}) (*string, error) {
+	if isNull(val) {
+		return nil, nil
+	}
+
+	v, err := StringE(val)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+// TimeN convert an interface to a *time.Time type, returns nil if the value is null, with default value
+func TimeN(v interface{}, def ...*time.Time) *time.Time {
+	if v, err := TimeNE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// TimeNE convert an interface to a *time.Time type, returns nil if the value is null
+func TimeNE(val interface{}) (*time.Time, error) {
+	if isNull(val) {
+		return nil, nil
+	}
+
+	v, err := TimeE(val)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+// DurationN convert an interface to a *time.Duration type, returns nil if the value is null, with default value
+func DurationN(v interface{}, def ...*time.Duration) *time.Duration {
+	if v, err := DurationNE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// DurationNE convert an interface to a *time.Duration type, returns nil if the value is null
+func DurationNE(val interface{}) (*time.Duration, error) {
+	if isNull(val) {
+		return nil, nil
+	}
+
+	v, err := DurationE(val)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
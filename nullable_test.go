@@ -0,0 +1,171 @@
+package cvt_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shockerli/cvt"
+)
+
+func TestIntNE(t *testing.T) {
+	tests := []struct {
+		input  interface{}
+		expect interface{}
+		isErr  bool
+	}{
+		// null
+		{nil, nil, false},
+		{pointerIntNil, nil, false},
+		{&pointerIntNil, nil, false},
+		{pointerInterNil, nil, false},
+		{"null", nil, false},
+		{" NULL ", nil, false},
+		{[]byte("null"), nil, false},
+		{AliasTypeString("null"), nil, false},
+
+		// zero is not null
+		{0, 0, false},
+		{"0", 0, false},
+		{false, 0, false},
+		{aliasTypeInt0, 0, false},
+		{&aliasTypeInt0, 0, false},
+		{"12", 12, false},
+		{12.3, 12, false},
+
+		// errors
+		{"", nil, true},
+		{"hello", nil, true},
+		{testing.T{}, nil, true},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, input[%+v], expect[%+v], isErr[%v]", i, tt.input, tt.expect, tt.isErr)
+
+		v, err := cvt.IntNE(tt.input)
+		if tt.isErr {
+			assertError(t, err, "[HasErr] "+msg)
+			continue
+		}
+
+		assertNoError(t, err, "[NoErr] "+msg)
+		if tt.expect == nil {
+			assertEqual(t, true, v == nil, "[WithE] "+msg)
+		} else {
+			assertEqual(t, tt.expect, *v, "[WithE] "+msg)
+		}
+	}
+}
+
+func TestIntN(t *testing.T) {
+	def := 5
+	assertEqual(t, true, cvt.IntN(nil) == nil)
+	assertEqual(t, true, cvt.IntN(nil, &def) == nil)
+	assertEqual(t, true, cvt.IntN("hello") == nil)
+	assertEqual(t, 5, *cvt.IntN("hello", &def))
+	assertEqual(t, 12, *cvt.IntN("12", &def))
+}
+
+func TestNE_EmptyStringAsNull(t *testing.T) {
+	defer func(b bool) {
+		cvt.EmptyStringAsNull = b
+	}(cvt.EmptyStringAsNull)
+
+	cvt.EmptyStringAsNull = false
+	s, err := cvt.StringNE("")
+	assertNoError(t, err)
+	assertEqual(t, "", *s)
+	_, err = cvt.Float64NE("")
+	assertError(t, err)
+
+	cvt.EmptyStringAsNull = true
+	s, err = cvt.StringNE(" ")
+	assertNoError(t, err)
+	assertEqual(t, true, s == nil)
+	f, err := cvt.Float64NE([]byte(""))
+	assertNoError(t, err)
+	assertEqual(t, true, f == nil)
+}
+
+func TestNE(t *testing.T) {
+	tests := []struct {
+		fn     func(interface{}) (interface{}, error)
+		input  interface{}
+		expect interface{}
+		isErr  bool
+	}{
+		{func(v interface{}) (interface{}, error) { return cvt.BoolNE(v) }, "yes", true, false},
+		{func(v interface{}) (interface{}, error) { return cvt.BoolNE(v) }, 0, false, false},
+		{func(v interface{}) (interface{}, error) { return cvt.BoolNE(v) }, "hello", nil, true},
+		{func(v interface{}) (interface{}, error) { return cvt.Int8NE(v) }, "8", int8(8), false},
+		{func(v interface{}) (interface{}, error) { return cvt.Int8NE(v) }, 1000, nil, true},
+		{func(v interface{}) (interface{}, error) { return cvt.Int16NE(v) }, "8", int16(8), false},
+		{func(v interface{}) (interface{}, error) { return cvt.Int32NE(v) }, "8", int32(8), false},
+		{func(v interface{}) (interface{}, error) { return cvt.Int64NE(v) }, "8", int64(8), false},
+		{func(v interface{}) (interface{}, error) { return cvt.UintNE(v) }, "8", uint(8), false},
+		{func(v interface{}) (interface{}, error) { return cvt.UintNE(v) }, -1, nil, true},
+		{func(v interface{}) (interface{}, error) { return cvt.Uint8NE(v) }, "8", uint8(8), false},
+		{func(v interface{}) (interface{}, error) { return cvt.Uint16NE(v) }, "8", uint16(8), false},
+		{func(v interface{}) (interface{}, error) { return cvt.Uint32NE(v) }, "8", uint32(8), false},
+		{func(v interface{}) (interface{}, error) { return cvt.Uint64NE(v) }, "8", uint64(8), false},
+		{func(v interface{}) (interface{}, error) { return cvt.Float32NE(v) }, "8.5", float32(8.5), false},
+		{func(v interface{}) (interface{}, error) { return cvt.Float64NE(v) }, "8.5", 8.5, false},
+		{func(v interface{}) (interface{}, error) { return cvt.StringNE(v) }, 8.5, "8.5", false},
+		{func(v interface{}) (interface{}, error) { return cvt.StringNE(v) }, "", "", false},
+		{func(v interface{}) (interface{}, error) { return cvt.TimeNE(v) }, 1234567890, time1, false},
+		{func(v interface{}) (interface{}, error) { return cvt.TimeNE(v) }, "hello", nil, true},
+		{func(v interface{}) (interface{}, error) { return cvt.DurationNE(v) }, "1h", time.Hour, false},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, input[%+v], expect[%+v], isErr[%v]", i, tt.input, tt.expect, tt.isErr)
+
+		v, err := tt.fn(tt.input)
+		if tt.isErr {
+			assertError(t, err, "[HasErr] "+msg)
+			continue
+		}
+
+		assertNoError(t, err, "[NoErr] "+msg)
+		if tm, ok := tt.expect.(time.Time); ok {
+			assertEqualTime(t, tm, *v.(*time.Time), "[WithE] "+msg)
+			continue
+		}
+		assertEqual(t, tt.expect, indirectValue(v), "[WithE] "+msg)
+
+		// null
+		v, err = tt.fn("null")
+		assertNoError(t, err, "[NoErr] "+msg)
+		assertEqual(t, true, isNilPointer(v), "[Null] "+msg)
+	}
+}
+
+func TestN(t *testing.T) {
+	assertEqual(t, true, cvt.BoolN(nil) == nil)
+	assertEqual(t, true, *cvt.BoolN("on"))
+	assertEqual(t, int8(8), *cvt.Int8N("8"))
+	assertEqual(t, int16(8), *cvt.Int16N("8"))
+	assertEqual(t, int32(8), *cvt.Int32N("8"))
+	assertEqual(t, int64(8), *cvt.Int64N("8"))
+	assertEqual(t, uint(8), *cvt.UintN("8"))
+	assertEqual(t, uint8(8), *cvt.Uint8N("8"))
+	assertEqual(t, uint16(8), *cvt.Uint16N("8"))
+	assertEqual(t, uint32(8), *cvt.Uint32N("8"))
+	assertEqual(t, uint64(8), *cvt.Uint64N("8"))
+	assertEqual(t, float32(8), *cvt.Float32N("8"))
+	assertEqual(t, float64(8), *cvt.Float64N("8"))
+	assertEqual(t, "8", *cvt.StringN(8))
+	assertEqualTime(t, time1, *cvt.TimeN(1234567890))
+	assertEqual(t, time.Second, *cvt.DurationN("1s"))
+	assertEqual(t, true, cvt.TimeN("null") == nil)
+}
+
+func indirectValue(v interface{}) interface{} {
+	return reflect.ValueOf(v).Elem().Interface()
+}
+
+func isNilPointer(v interface{}) bool {
+	v, _ = cvt.Indirect(v)
+	return v == nil
+}
@@ -0,0 +1,332 @@
+package cvt
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var errInvalidPath = errors.New("invalid path")
+var errIndexOutOfRange = errors.New("index out of range")
+
+// PathError the error of resolving a path, with the segment where resolution failed
+//
+//	path "user.addresses[5].city" at "[5]": index out of range(5), length 2
+type PathError struct {
+	Path    string // the full path
+	Segment string // the segment where resolution failed, eg: "[5]", "city"
+	Err     error
+}
+
+func (e *PathError) Error() string {
+	return fmt.Sprintf("path %q at %q: %s", e.Path, e.Segment, e.Err)
+}
+
+// Unwrap returns the error of segment
+func (e *PathError) Unwrap() error {
+	return e.Err
+}
+
+// the segment of path
+type pathSegment struct {
+	raw     string // the raw text, eg: "name", "[0]", `["a.b"]`
+	key     string // the key or field name
+	index   int    // the index of slice/array, if isIndex
+	isIndex bool
+}
+
+// Path return the value of nested path from map/struct/slice/array, with default value
+func Path(v interface{}, path string, def ...interface{}) interface{} {
+	if v, err := PathE(v, path); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// PathE return the value of nested path from map/struct/slice/array, the pointer is dereferenced
+// * the field or key is separated by dot, eg: "user.name"
+// * the index of slice/array is in brackets, or a number after dot, eg: "items[0]", "items.0"
+// * the key with special chars is quoted in brackets, the quote and backslash are escaped by backslash, eg: `tags["a.b"]`, `tags['it\'s']`
+// * returns a *PathError with the segment where resolution failed
+//
+//	cvt.PathE(data, "user.addresses[0].city")
+func PathE(val interface{}, path string) (interface{}, error) {
+	segs, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return walkPath(val, path, segs)
+}
+
+// walk the value by the segments of path
+func walkPath(val interface{}, path string, segs []pathSegment) (v interface{}, err error) {
+	for _, seg := range segs {
+		if val, err = pathStep(val, seg); err != nil {
+			return nil, &PathError{Path: path, Segment: seg.raw, Err: err}
+		}
+	}
+	return val, nil
+}
+
+// resolve a segment of the value
+func pathStep(val interface{}, seg pathSegment) (interface{}, error) {
+	if val == nil {
+		return nil, errUnsupportedTypeNil
+	}
+
+	_, rv := Indirect(val)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		if !seg.isIndex {
+			break
+		}
+		if seg.index >= rv.Len() {
+			return nil, fmt.Errorf("%w(%d), length %d", errIndexOutOfRange, seg.index, rv.Len())
+		}
+		if vv := rv.Index(seg.index); vv.CanInterface() {
+			return vv.Interface(), nil
+		}
+	case reflect.Map, reflect.Struct:
+		if v, ok := fieldValue(val, seg.key); ok {
+			return v, nil
+		}
+	case reflect.Invalid:
+		return nil, errUnsupportedTypeNil
+	}
+
+	return nil, fmt.Errorf("%w(%s)", errFieldNotFound, seg.key)
+}
+
+// parse the path to segments
+//
+//	`user.addresses[0]["zip.code"]` => user, addresses, 0, zip.code
+func parsePath(path string) ([]pathSegment, error) {
+	var segs []pathSegment
+	fail := func(pos int) ([]pathSegment, error) {
+		return nil, &PathError{Path: path, Segment: path[pos:], Err: errInvalidPath}
+	}
+	if path == "" {
+		return fail(0)
+	}
+
+	for i := 0; ; {
+		start := i
+		if path[i] == '[' {
+			end, key, quoted, ok := scanBracket(path, i)
+			if !ok {
+				return fail(start)
+			}
+			seg := pathSegment{raw: path[start:end], key: key}
+			if !quoted {
+				seg.index, seg.isIndex = pathIndex(key)
+			}
+			segs = append(segs, seg)
+			i = end
+		} else {
+			for i < len(path) && path[i] != '.' && path[i] != '[' {
+				i++
+			}
+			if i == start {
+				return fail(start) // empty name, eg: "a..b", ".a"
+			}
+			seg := pathSegment{raw: path[start:i], key: path[start:i]}
+			seg.index, seg.isIndex = pathIndex(seg.key)
+			segs = append(segs, seg)
+		}
+
+		if i == len(path) {
+			break
+		}
+		// a name is required after dot, eg: "a.b", not "a." or "a.[0]"
+		if path[i] == '.' {
+			i++
+			if i == len(path) || path[i] == '[' {
+				return fail(i - 1)
+			}
+		} else if path[i] != '[' {
+			return fail(i)
+		}
+	}
+
+	return segs, nil
+}
+
+// returns the index of the non-negative integer key
+func pathIndex(key string) (int, bool) {
+	n, err := strconv.Atoi(key)
+	return n, err == nil && n >= 0
+}
+
+// scan the bracket segment from path[i], which is '['
+// returns the end position after ']', the key, and whether the key is quoted
+func scanBracket(path string, i int) (end int, key string, quoted, ok bool) {
+	i++
+	if i < len(path) && (path[i] == '"' || path[i] == '\'') {
+		q := path[i]
+		var b strings.Builder
+		for i++; i < len(path); i++ {
+			c := path[i]
+			if c == '\\' && i+1 < len(path) {
+				i++
+				b.WriteByte(path[i])
+				continue
+			}
+			if c == q {
+				if i+1 < len(path) && path[i+1] == ']' {
+					return i + 2, b.String(), true, true
+				}
+				return
+			}
+			b.WriteByte(c)
+		}
+		return
+	}
+
+	j := strings.IndexByte(path[i:], ']')
+	if j <= 0 {
+		return
+	}
+	return i + j + 1, path[i : i+j], false, true
+}
+
+// SetFieldE set the value of nested path in the target, the value is converted to the type of field
+// * the target is a non-nil pointer, or a non-nil map
+// * the path syntax is same as PathE, the struct field is matched by the field name, the name of StructTag, or FieldMatch
+// * the value is converted by the converters of field type, eg: "42" to int, timestamp to time.Time
+// * the nil map and pointer on the path are created, the nil interface is created as `map[string]interface{}`
+// * the slice is grown to the index, by at most 1024 elements
+// * the target is not modified if it fails, eg: the nil map and pointer are kept
+// * returns a *PathError with the segment where setting failed
+//
+//	cvt.SetFieldE(&user, "addresses[0].city", "Beijing")
+//	cvt.SetFieldE(&user, "created", 1234567890)
+func SetFieldE(target interface{}, path string, value interface{}) error {
+	rv := reflect.ValueOf(target)
+	if target == nil || (rv.Kind() != reflect.Ptr && rv.Kind() != reflect.Map) || rv.IsNil() {
+		return fmt.Errorf("%w, the target must be a non-nil pointer or map, got %T", errConvFail, target)
+	}
+	segs, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+
+	if rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	return setPath(rv, path, segs, value)
+}
+
+// the max number of elements that SetFieldE grows the slice by
+const maxSliceGrow = 1024
+
+// set the value at segments of rv, the rv is settable, or a non-nil map
+// the rv is modified only if it succeeds, the containers created on the path are set at last
+func setPath(rv reflect.Value, path string, segs []pathSegment, value interface{}) error {
+	if len(segs) == 0 {
+		v, err := convValue(value, rv.Type())
+		if err != nil {
+			return err
+		}
+		rv.Set(v)
+		return nil
+	}
+
+	seg := segs[0]
+	// the error of nested segment is a PathError already
+	fail := func(err error) error {
+		if _, ok := err.(*PathError); ok {
+			return err
+		}
+		return &PathError{Path: path, Segment: seg.raw, Err: err}
+	}
+
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if !rv.IsNil() {
+			return setPath(rv.Elem(), path, segs, value)
+		}
+		p := reflect.New(rv.Type().Elem())
+		if err := setPath(p.Elem(), path, segs, value); err != nil {
+			return err
+		}
+		rv.Set(p)
+		return nil
+	case reflect.Interface:
+		// the element of interface is not settable, set to a copy
+		var nv reflect.Value
+		if rv.IsNil() {
+			nv = reflect.ValueOf(make(map[string]interface{}))
+		} else {
+			nv = reflect.New(rv.Elem().Type()).Elem()
+			nv.Set(rv.Elem())
+		}
+		if err := setPath(nv, path, segs, value); err != nil {
+			return err
+		}
+		rv.Set(nv)
+		return nil
+	case reflect.Struct:
+		// set to a copy, the nil embedded pointer may be allocated
+		nv := reflect.New(rv.Type()).Elem()
+		nv.Set(rv)
+		field := fieldByName(nv, seg.key, true)
+		if !field.IsValid() || !field.CanSet() {
+			return fail(fmt.Errorf("%w(%s)", errFieldNotFound, seg.key))
+		}
+		if err := setPath(field, path, segs[1:], value); err != nil {
+			return fail(err)
+		}
+		rv.Set(nv)
+		return nil
+	case reflect.Map:
+		key, err := convValue(seg.key, rv.Type().Key())
+		if err != nil {
+			return fail(err)
+		}
+		// the element of map is not settable, set to a copy
+		elem := reflect.New(rv.Type().Elem()).Elem()
+		if v := rv.MapIndex(key); v.IsValid() {
+			elem.Set(v)
+		}
+		if err := setPath(elem, path, segs[1:], value); err != nil {
+			return fail(err)
+		}
+		if rv.IsNil() {
+			rv.Set(reflect.MakeMap(rv.Type()))
+		}
+		rv.SetMapIndex(key, elem)
+		return nil
+	case reflect.Slice, reflect.Array:
+		if !seg.isIndex {
+			return fail(fmt.Errorf("%w(%s)", errFieldNotFound, seg.key))
+		}
+		if seg.index < rv.Len() {
+			if err := setPath(rv.Index(seg.index), path, segs[1:], value); err != nil {
+				return fail(err)
+			}
+			return nil
+		}
+		if rv.Kind() == reflect.Array || !rv.CanSet() || seg.index-rv.Len() >= maxSliceGrow {
+			return fail(fmt.Errorf("%w(%d), length %d", errIndexOutOfRange, seg.index, rv.Len()))
+		}
+		// grow a copy of the slice
+		n := seg.index + 1
+		nv := reflect.MakeSlice(rv.Type(), n, n)
+		reflect.Copy(nv, rv)
+		if err := setPath(nv.Index(seg.index), path, segs[1:], value); err != nil {
+			return fail(err)
+		}
+		rv.Set(nv)
+		return nil
+	}
+
+	return fail(fmt.Errorf("%w(%s)", errFieldNotFound, seg.key))
+}
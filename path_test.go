@@ -0,0 +1,268 @@
+package cvt_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/shockerli/cvt"
+)
+
+func TestPathE(t *testing.T) {
+	data := map[string]interface{}{
+		"user": map[string]interface{}{
+			"name": "bob",
+			"addresses": []interface{}{
+				map[string]interface{}{"city": "Beijing", "zip.code": "100000"},
+				&TestStructTag{ID: 2, TestStructTagEmbed: TestStructTagEmbed{Level: 3}},
+			},
+			"it's": [2]int{1, 2},
+			`a"b`:  true,
+		},
+		"a.b": 1,
+		"nil": nil,
+	}
+
+	tests := []struct {
+		input  interface{}
+		path   string
+		expect interface{}
+		isErr  bool
+	}{
+		{data, "user.name", "bob", false},
+		{data, "user.addresses[0].city", "Beijing", false},
+		{data, "user.addresses.0.city", "Beijing", false},
+		{data, `user.addresses[0]["zip.code"]`, "100000", false},
+		{data, `user.addresses[0]['zip.code']`, "100000", false},
+		{data, "user.addresses[1].id", 2, false},
+		{data, "user.addresses[1].level", 3, false},
+		{data, `user['it\'s'][1]`, 2, false},
+		{data, `user["a\"b"]`, true, false},
+		{data, `["a.b"]`, 1, false},
+		{data, "nil", nil, false},
+		{map[int]string{1: "a"}, "[1]", "a", false},
+		{[][]int{{1}, {2, 3}}, "[1][1]", 3, false},
+		{&TestStructE{DD: &TestStructD{D1: 2}}, "DD.D1", 2, false},
+
+		// errors
+		{data, "", nil, true},
+		{data, "user.", nil, true},
+		{data, ".user", nil, true},
+		{data, "user..name", nil, true},
+		{data, "user.[0]", nil, true},
+		{data, "user[0", nil, true},
+		{data, "user[]", nil, true},
+		{data, `user["name]`, nil, true},
+		{data, `user["name"`, nil, true},
+		{data, `user["name"]x`, nil, true},
+		{data, "user.age", nil, true},
+		{data, "user.addresses[2]", nil, true},
+		{data, "user.addresses.name", nil, true},
+		{data, "user.name.first", nil, true},
+		{data, "nil.name", nil, true},
+		{nil, "name", nil, true},
+		{&TestStructE{}, "DD.D1", nil, true},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, input[%+v], path[%s], expect[%+v], isErr[%v]", i, tt.input, tt.path, tt.expect, tt.isErr)
+
+		v, err := cvt.PathE(tt.input, tt.path)
+		if tt.isErr {
+			assertError(t, err, "[HasErr] "+msg)
+			var e *cvt.PathError
+			assertEqual(t, true, errors.As(err, &e), "[PathError] "+msg)
+			continue
+		}
+
+		assertNoError(t, err, msg)
+		assertEqual(t, tt.expect, v, "[WithE] "+msg)
+
+		// non-E
+		v = cvt.Path(tt.input, tt.path, "def")
+		assertEqual(t, tt.expect, v, "[NonE] "+msg)
+	}
+	assertEqual(t, "def", cvt.Path(data, "user.age", "def"))
+	assertEqual(t, nil, cvt.Path(data, "user.age"))
+}
+
+func TestPathE_Segment(t *testing.T) {
+	data := map[string]interface{}{
+		"user": map[string]interface{}{
+			"addresses": []interface{}{map[string]interface{}{"city": "Beijing"}},
+		},
+	}
+
+	tests := []struct {
+		path    string
+		segment string
+	}{
+		{"user.addresses[5].city", "[5]"},
+		{"user.address[0]", "address"},
+		{"user.addresses[0].zip", "zip"},
+		{`user.addresses[0]["zip"]`, `["zip"]`},
+		{"user.addresses[0].city.name", "name"},
+		{"user..city", ".city"},
+		{"user[0", "[0"},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, path[%s], segment[%s]", i, tt.path, tt.segment)
+
+		_, err := cvt.PathE(data, tt.path)
+		var e *cvt.PathError
+		assertEqual(t, true, errors.As(err, &e), "[PathError] "+msg)
+		assertEqual(t, tt.path, e.Path, "[Path] "+msg)
+		assertEqual(t, tt.segment, e.Segment, "[Segment] "+msg)
+	}
+
+	_, err := cvt.PathE(data, "user.addresses[5].city")
+	assertEqual(t, `path "user.addresses[5].city" at "[5]": index out of range(5), length 1`, err.Error())
+}
+
+func TestFieldE_Path(t *testing.T) {
+	data := map[string]interface{}{
+		"a.b": 1,
+		"a":   map[string]interface{}{"b": 2, "c": []int{3}},
+	}
+
+	// the key is matched first
+	assertEqual(t, 1, cvt.Field(data, "a.b"))
+	assertEqual(t, 3, cvt.Field(data, "a.c[0]"))
+	assertEqual(t, nil, cvt.Field(data, "a.d"))
+
+	_, err := cvt.FieldE(data, "a.c[1]")
+	var e *cvt.PathError
+	assertEqual(t, true, errors.As(err, &e))
+	assertEqual(t, "[1]", e.Segment)
+}
+
+type TestSetAddress struct {
+	City string `json:"city"`
+	Zip  int    `json:"zip"`
+}
+
+type TestSetUser struct {
+	*TestDecodeBase
+	Name      string
+	Age       int              `json:"age"`
+	Addresses []TestSetAddress `json:"addresses"`
+	Home      *TestSetAddress  `json:"home"`
+	Tags      map[string]int   `json:"tags"`
+	Extra     interface{}      `json:"extra"`
+	Scores    [2]float64       `json:"scores"`
+	private   int
+}
+
+func TestSetFieldE(t *testing.T) {
+	var u TestSetUser
+	assertNoError(t, cvt.SetFieldE(&u, "Name", 123))
+	assertNoError(t, cvt.SetFieldE(&u, "age", "42"))
+	assertNoError(t, cvt.SetFieldE(&u, "Age", "43"))
+	assertNoError(t, cvt.SetFieldE(&u, "created", 1234567890))
+	assertNoError(t, cvt.SetFieldE(&u, "ID", "7"))
+	assertNoError(t, cvt.SetFieldE(&u, "addresses[1].city", "Beijing"))
+	assertNoError(t, cvt.SetFieldE(&u, "addresses.0.zip", "100000"))
+	assertNoError(t, cvt.SetFieldE(&u, "home.city", "Shanghai"))
+	assertNoError(t, cvt.SetFieldE(&u, `tags["a.b"]`, "1"))
+	assertNoError(t, cvt.SetFieldE(&u, "extra.a[0]", 1))
+	assertNoError(t, cvt.SetFieldE(&u, "scores[1]", "1.5"))
+	assertEqual(t, TestSetUser{
+		TestDecodeBase: &TestDecodeBase{ID: 7, Created: time.Unix(1234567890, 0)},
+		Name:           "123",
+		Age:            43,
+		Addresses:      []TestSetAddress{{Zip: 100000}, {City: "Beijing"}},
+		Home:           &TestSetAddress{City: "Shanghai"},
+		Tags:           map[string]int{"a.b": 1},
+		Extra:          map[string]interface{}{"a": map[string]interface{}{"0": 1}},
+		Scores:         [2]float64{0, 1.5},
+	}, u)
+
+	// the whole field
+	assertNoError(t, cvt.SetFieldE(&u, "home", map[string]interface{}{"city": "x", "zip": "1"}))
+	assertEqual(t, &TestSetAddress{City: "x", Zip: 1}, u.Home)
+	assertNoError(t, cvt.SetFieldE(&u, "addresses", `[{"city": "y"}]`))
+	assertEqual(t, []TestSetAddress{{City: "y"}}, u.Addresses)
+
+	// map and slice target
+	m := map[string]interface{}{"user": map[string]interface{}{"tags": []interface{}{"a"}}}
+	assertNoError(t, cvt.SetFieldE(m, "user.tags[0]", "b"))
+	assertNoError(t, cvt.SetFieldE(m, "user.name", "bob"))
+	assertNoError(t, cvt.SetFieldE(m, "count", 1))
+	assertEqual(t, map[string]interface{}{
+		"user":  map[string]interface{}{"tags": []interface{}{"b"}, "name": "bob"},
+		"count": 1,
+	}, m)
+
+	var nm map[int][]string
+	assertNoError(t, cvt.SetFieldE(&nm, "[1][2]", 3))
+	assertEqual(t, map[int][]string{1: {"", "", "3"}}, nm)
+
+	sl := []*TestSetAddress{nil}
+	assertNoError(t, cvt.SetFieldE(&sl, "[0].zip", "1"))
+	assertEqual(t, []*TestSetAddress{{Zip: 1}}, sl)
+
+	var p *int
+	assertError(t, cvt.SetFieldE(&p, "[0]", 1))
+}
+
+func TestSetFieldE_Error(t *testing.T) {
+	tests := []struct {
+		path    string
+		value   interface{}
+		segment string
+	}{
+		{"age", "abc", "age"},
+		{"addresses[0].zip", "abc", "zip"},
+		{"home.street", "abc", "street"},
+		{"Unknown", 1, "Unknown"},
+		{"private", 1, "private"},
+		{"scores[2]", 1, "[2]"},
+		{"addresses.city", 1, "city"},
+		{"Name.first", 1, "first"},
+		{"tags[a]", "b", "[a]"},
+		{"created", "abc", "created"},
+		{"user..name", 1, ".name"},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, path[%s], value[%+v], segment[%s]", i, tt.path, tt.value, tt.segment)
+
+		var u TestSetUser
+		err := cvt.SetFieldE(&u, tt.path, tt.value)
+		var e *cvt.PathError
+		assertEqual(t, true, errors.As(err, &e), "[PathError] "+msg)
+		assertEqual(t, tt.segment, e.Segment, "[Segment] "+msg)
+	}
+
+	var u TestSetUser
+	err := cvt.SetFieldE(&u, "addresses[0].zip", "abc")
+	assertEqual(t, `path "addresses[0].zip" at "zip": unable to convert "abc" of type string to int, strconv.ParseFloat: parsing "abc": invalid syntax`, err.Error())
+
+	// the slice is grown by at most 1024 elements
+	err = cvt.SetFieldE(&u, "addresses[1000000000].city", "x")
+	var pe *cvt.PathError
+	assertEqual(t, true, errors.As(err, &pe))
+	assertEqual(t, "[1000000000]", pe.Segment)
+	assertNoError(t, cvt.SetFieldE(&u, "addresses[1023].city", "x"))
+	assertEqual(t, 1024, len(u.Addresses))
+
+	// the target is not modified if it fails
+	var u2 TestSetUser
+	assertError(t, cvt.SetFieldE(&u2, "home.zip", "abc"))
+	assertError(t, cvt.SetFieldE(&u2, "ID", "abc"))
+	assertError(t, cvt.SetFieldE(&u2, "addresses[2].zip", "abc"))
+	assertError(t, cvt.SetFieldE(&u2, "tags.a", "abc"))
+	assertEqual(t, TestSetUser{}, u2)
+	var nm map[string]*TestSetAddress
+	assertError(t, cvt.SetFieldE(&nm, "a.zip", "abc"))
+	assertEqual(t, true, nm == nil)
+
+	// invalid target
+	assertError(t, cvt.SetFieldE(nil, "a", 1))
+	assertError(t, cvt.SetFieldE(u, "age", 1))
+	assertError(t, cvt.SetFieldE((*TestSetUser)(nil), "age", 1))
+	assertError(t, cvt.SetFieldE(map[string]int(nil), "a", 1))
+	assertError(t, cvt.SetFieldE([]int{1}, "[0]", 1))
+}
@@ -0,0 +1,416 @@
+package cvt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var errPatchTest = errors.New("test failed")
+
+// Pointer return the value of JSON Pointer(RFC 6901) from map/struct/slice/array, with default value
+func Pointer(v interface{}, pointer string, def ...interface{}) interface{} {
+	if v, err := PointerE(v, pointer); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// PointerE return the value of JSON Pointer(RFC 6901) from map/struct/slice/array, same traversal as PathE
+// * "" is the whole value, otherwise the pointer starts with "/"
+// * "~1" is escaped "/", "~0" is escaped "~"
+// * returns a *PathError with the token where resolution failed
+//
+//	cvt.PointerE(data, "/items/0/price")
+//	cvt.PointerE(data, "/a~1b") // the key "a/b"
+func PointerE(val interface{}, pointer string) (interface{}, error) {
+	segs, err := parsePointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+
+	return walkPath(val, pointer, segs)
+}
+
+// PointerInt return the int value of JSON Pointer, with default value
+func PointerInt(v interface{}, pointer string, def ...int) int {
+	if v, err := PointerIntE(v, pointer); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return 0
+}
+
+// PointerIntE return the int value of JSON Pointer, converted by IntE
+func PointerIntE(val interface{}, pointer string) (int, error) {
+	v, err := PointerE(val, pointer)
+	if err != nil {
+		return 0, err
+	}
+	return IntE(v)
+}
+
+// PointerString return the string value of JSON Pointer, with default value
+func PointerString(v interface{}, pointer string, def ...string) string {
+	if v, err := PointerStringE(v, pointer); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return ""
+}
+
+// PointerStringE return the string value of JSON Pointer, converted by StringE
+func PointerStringE(val interface{}, pointer string) (string, error) {
+	v, err := PointerE(val, pointer)
+	if err != nil {
+		return "", err
+	}
+	return StringE(v)
+}
+
+// parse the JSON Pointer to segments
+//
+//	"/a~1b/0" => a/b, 0
+func parsePointer(pointer string) ([]pathSegment, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if pointer[0] != '/' {
+		return nil, &PathError{Path: pointer, Segment: pointer, Err: errInvalidPath}
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	segs := make([]pathSegment, len(tokens))
+	for j, token := range tokens {
+		key, ok := unescapePointer(token)
+		if !ok {
+			return nil, &PathError{Path: pointer, Segment: "/" + token, Err: errInvalidPath}
+		}
+		segs[j] = pathSegment{raw: "/" + token, key: key}
+		// the array index has no leading zeros
+		if token == "0" || token != "" && token[0] != '0' {
+			segs[j].index, segs[j].isIndex = pathIndex(token)
+		}
+	}
+	return segs, nil
+}
+
+// unescape the token of JSON Pointer, returns false for the invalid escape
+func unescapePointer(token string) (string, bool) {
+	if strings.IndexByte(token, '~') < 0 {
+		return token, true
+	}
+
+	var b strings.Builder
+	for j := 0; j < len(token); j++ {
+		if token[j] != '~' {
+			b.WriteByte(token[j])
+			continue
+		}
+		if j+1 == len(token) {
+			return "", false
+		}
+		j++
+		switch token[j] {
+		case '0':
+			b.WriteByte('~')
+		case '1':
+			b.WriteByte('/')
+		default:
+			return "", false
+		}
+	}
+	return b.String(), true
+}
+
+// Patch apply the JSON Patch(RFC 6902) operations to the document, with default value
+func Patch(doc interface{}, patch interface{}, def ...map[string]interface{}) map[string]interface{} {
+	if v, err := PatchE(doc, patch); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// PatchE apply the JSON Patch(RFC 6902) operations to the document, returns a new document
+// * the doc is converted by StringMapDeepE, so it can be map, struct or JSON object, and it's not modified
+// * the patch is a JSON array, or a slice of operations, such as `[]map[string]interface{}`
+// * the operations are add, remove, replace, move, copy and test
+// * the numbers are compared by value in the test operation, eg: 1 equals to float64(1) and json.Number("1")
+// * returns an *ElementError with the index of failed operation, the document is not patched
+//
+//	cvt.PatchE(doc, `[{"op": "replace", "path": "/items/0/price", "value": 9.9}]`)
+func PatchE(doc interface{}, patch interface{}) (map[string]interface{}, error) {
+	m, err := StringMapDeepE(doc)
+	if err != nil {
+		return nil, err
+	}
+	ops, err := SliceE(patch)
+	if err != nil {
+		return nil, err
+	}
+
+	var root interface{} = m
+	for j, op := range ops {
+		if root, err = applyPatch(root, op); err != nil {
+			return nil, &ElementError{Index: j, Err: err}
+		}
+	}
+
+	if m, ok := root.(map[string]interface{}); ok {
+		return m, nil
+	}
+	return nil, newErr(root, "map[string]interface{}")
+}
+
+// apply an operation of JSON Patch, returns the new root
+func applyPatch(root interface{}, val interface{}) (interface{}, error) {
+	op, err := StringMapE(val)
+	if err != nil {
+		return nil, err
+	}
+
+	// the required members of operation
+	member := func(name string) (string, []pathSegment, error) {
+		v, ok := op[name]
+		if !ok {
+			return "", nil, fmt.Errorf("%w(%s)", errFieldNotFound, name)
+		}
+		pointer, err := StringE(v)
+		if err != nil {
+			return "", nil, err
+		}
+		segs, err := parsePointer(pointer)
+		return pointer, segs, err
+	}
+	value := func() (interface{}, error) {
+		v, ok := op["value"]
+		if !ok {
+			return nil, fmt.Errorf("%w(value)", errFieldNotFound)
+		}
+		return deepValue(v)
+	}
+
+	path, segs, err := member("path")
+	if err != nil {
+		return nil, err
+	}
+
+	switch name := String(op["op"]); name {
+	case "add", "replace":
+		v, err := value()
+		if err != nil {
+			return nil, err
+		}
+		return patchAdd(root, path, segs, v, name == "replace")
+	case "remove":
+		root, _, err = patchRemove(root, path, segs)
+		return root, err
+	case "move", "copy":
+		from, fromSegs, err := member("from")
+		if err != nil {
+			return nil, err
+		}
+		var v interface{}
+		if name == "move" {
+			if strings.HasPrefix(path, from+"/") {
+				return nil, &PathError{Path: path, Segment: path, Err: fmt.Errorf("%w, move to the child of %q", errInvalidPath, from)}
+			}
+			if root, v, err = patchRemove(root, from, fromSegs); err != nil {
+				return nil, err
+			}
+		} else {
+			if v, err = walkPath(root, from, fromSegs); err != nil {
+				return nil, err
+			}
+			if v, err = deepValue(v); err != nil {
+				return nil, err
+			}
+		}
+		return patchAdd(root, path, segs, v, false)
+	case "test":
+		v, err := value()
+		if err != nil {
+			return nil, err
+		}
+		actual, err := walkPath(root, path, segs)
+		if err != nil {
+			return nil, err
+		}
+		if !jsonEqual(actual, v) {
+			return nil, &PathError{Path: path, Segment: path, Err: errPatchTest}
+		}
+		return root, nil
+	default:
+		return nil, fmt.Errorf("%w, unknown op %q", errConvFail, name)
+	}
+}
+
+// add or replace the value at segments of node, returns the new node
+func patchAdd(node interface{}, path string, segs []pathSegment, v interface{}, replace bool) (interface{}, error) {
+	if len(segs) == 0 {
+		return v, nil
+	}
+
+	seg := segs[0]
+	fail := func(err error) (interface{}, error) {
+		return nil, &PathError{Path: path, Segment: seg.raw, Err: err}
+	}
+
+	switch n := node.(type) {
+	case map[string]interface{}:
+		child, ok := n[seg.key]
+		if !ok && (replace || len(segs) > 1) {
+			return fail(fmt.Errorf("%w(%s)", errFieldNotFound, seg.key))
+		}
+		child, err := patchAdd(child, path, segs[1:], v, replace)
+		if err != nil {
+			return nil, err
+		}
+		n[seg.key] = child
+		return n, nil
+	case []interface{}:
+		last := len(segs) == 1 && !replace
+		if last && seg.key == "-" {
+			return append(n, v), nil
+		}
+		if !seg.isIndex || seg.index > len(n) || seg.index == len(n) && !last {
+			return fail(fmt.Errorf("%w(%s), length %d", errIndexOutOfRange, seg.key, len(n)))
+		}
+		if last {
+			n = append(n, nil)
+			copy(n[seg.index+1:], n[seg.index:])
+			n[seg.index] = v
+			return n, nil
+		}
+		child, err := patchAdd(n[seg.index], path, segs[1:], v, replace)
+		if err != nil {
+			return nil, err
+		}
+		n[seg.index] = child
+		return n, nil
+	}
+
+	return fail(fmt.Errorf("%w(%s)", errFieldNotFound, seg.key))
+}
+
+// remove the value at segments of node, returns the new node and the removed value
+func patchRemove(node interface{}, path string, segs []pathSegment) (interface{}, interface{}, error) {
+	if len(segs) == 0 {
+		return nil, nil, &PathError{Path: path, Segment: path, Err: fmt.Errorf("%w, remove the whole document", errInvalidPath)}
+	}
+
+	seg := segs[0]
+	fail := func(err error) (interface{}, interface{}, error) {
+		return nil, nil, &PathError{Path: path, Segment: seg.raw, Err: err}
+	}
+
+	switch n := node.(type) {
+	case map[string]interface{}:
+		child, ok := n[seg.key]
+		if !ok {
+			return fail(fmt.Errorf("%w(%s)", errFieldNotFound, seg.key))
+		}
+		if len(segs) == 1 {
+			delete(n, seg.key)
+			return n, child, nil
+		}
+		child, removed, err := patchRemove(child, path, segs[1:])
+		if err != nil {
+			return nil, nil, err
+		}
+		n[seg.key] = child
+		return n, removed, nil
+	case []interface{}:
+		if !seg.isIndex || seg.index >= len(n) {
+			return fail(fmt.Errorf("%w(%s), length %d", errIndexOutOfRange, seg.key, len(n)))
+		}
+		if len(segs) == 1 {
+			removed := n[seg.index]
+			return append(n[:seg.index], n[seg.index+1:]...), removed, nil
+		}
+		child, removed, err := patchRemove(n[seg.index], path, segs[1:])
+		if err != nil {
+			return nil, nil, err
+		}
+		n[seg.index] = child
+		return n, removed, nil
+	}
+
+	return fail(fmt.Errorf("%w(%s)", errFieldNotFound, seg.key))
+}
+
+// convert the value to the tree of `map[string]interface{}` and `[]interface{}`, same as StringMapDeepE
+func deepValue(val interface{}) (interface{}, error) {
+	w := deepWalker{visiting: make(map[deepVisit]bool)}
+	v, err := w.walk(reflect.ValueOf(val), "", 0)
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert value of type %T, %w", val, err)
+	}
+	return v, nil
+}
+
+// compare the values of JSON, the numbers are compared by value
+func jsonEqual(a, b interface{}) bool {
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for k, v := range av {
+			if vv, ok := bv[k]; !ok || !jsonEqual(v, vv) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for j := range av {
+			if !jsonEqual(av[j], bv[j]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if isJSONNumber(a) && isJSONNumber(b) {
+		return Float64(a) == Float64(b)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func isJSONNumber(v interface{}) bool {
+	if _, ok := v.(json.Number); ok {
+		return true
+	}
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
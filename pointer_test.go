@@ -0,0 +1,215 @@
+package cvt_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/shockerli/cvt"
+)
+
+func TestPointerE(t *testing.T) {
+	// the example of RFC 6901
+	var doc map[string]interface{}
+	_ = json.Unmarshal([]byte(`{
+		"foo": ["bar", "baz"],
+		"": 0,
+		"a/b": 1,
+		"c%d": 2,
+		"e^f": 3,
+		"g|h": 4,
+		"i\\j": 5,
+		"k\"l": 6,
+		" ": 7,
+		"m~n": 8
+	}`), &doc)
+
+	tests := []struct {
+		input   interface{}
+		pointer string
+		expect  interface{}
+		isErr   bool
+	}{
+		{doc, "", doc, false},
+		{doc, "/foo", []interface{}{"bar", "baz"}, false},
+		{doc, "/foo/0", "bar", false},
+		{doc, "/", float64(0), false},
+		{doc, "/a~1b", float64(1), false},
+		{doc, "/c%d", float64(2), false},
+		{doc, "/e^f", float64(3), false},
+		{doc, "/g|h", float64(4), false},
+		{doc, "/i\\j", float64(5), false},
+		{doc, "/k\"l", float64(6), false},
+		{doc, "/ ", float64(7), false},
+		{doc, "/m~0n", float64(8), false},
+		{map[string]interface{}{"items": []TestDecodeItem{{"a", 1.5}}}, "/items/0/price", 1.5, false},
+		{map[string]interface{}{"items": []TestDecodeItem{{"a", 1.5}}}, "/items/0/Name", "a", false},
+		{map[string]interface{}{"01": 1}, "/01", 1, false},
+		{map[string]interface{}{"a.b": map[string]int{"[0]": 1}}, "/a.b/[0]", 1, false},
+
+		// errors
+		{doc, "foo", nil, true},
+		{doc, "/m~n", nil, true},
+		{doc, "/m~", nil, true},
+		{doc, "/foo/2", nil, true},
+		{doc, "/foo/-", nil, true},
+		{doc, "/foo/01", nil, true},
+		{doc, "/foo/-1", nil, true},
+		{doc, "/bar", nil, true},
+		{doc, "/foo/0/a", nil, true},
+		{nil, "/a", nil, true},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, input[%+v], pointer[%s], expect[%+v], isErr[%v]", i, tt.input, tt.pointer, tt.expect, tt.isErr)
+
+		v, err := cvt.PointerE(tt.input, tt.pointer)
+		if tt.isErr {
+			assertError(t, err, "[HasErr] "+msg)
+			var e *cvt.PathError
+			assertEqual(t, true, errors.As(err, &e), "[PathError] "+msg)
+			continue
+		}
+
+		assertNoError(t, err, msg)
+		assertEqual(t, tt.expect, v, "[WithE] "+msg)
+
+		// non-E
+		v = cvt.Pointer(tt.input, tt.pointer, "def")
+		assertEqual(t, tt.expect, v, "[NonE] "+msg)
+	}
+
+	_, err := cvt.PointerE(doc, "/foo/2/a")
+	assertEqual(t, `path "/foo/2/a" at "/2": index out of range(2), length 2`, err.Error())
+	assertEqual(t, "def", cvt.Pointer(doc, "/bar", "def"))
+}
+
+func TestPointerTypedE(t *testing.T) {
+	data := map[string]interface{}{
+		"items": []interface{}{map[string]interface{}{"price": "12", "qty": json.Number("3")}},
+	}
+
+	v1, err := cvt.PointerIntE(data, "/items/0/price")
+	assertNoError(t, err)
+	assertEqual(t, 12, v1)
+	assertEqual(t, 3, cvt.PointerInt(data, "/items/0/qty"))
+	assertEqual(t, 1, cvt.PointerInt(data, "/items/1/qty", 1))
+	assertEqual(t, 0, cvt.PointerInt(data, "/items"))
+
+	v2, err := cvt.PointerStringE(data, "/items/0/qty")
+	assertNoError(t, err)
+	assertEqual(t, "3", v2)
+	assertEqual(t, "12", cvt.PointerString(data, "/items/0/price"))
+	assertEqual(t, "x", cvt.PointerString(data, "/items/0/name", "x"))
+	assertEqual(t, "", cvt.PointerString(data, "/items"))
+
+	_, err = cvt.PointerIntE(data, "/items/0/name")
+	assertError(t, err)
+	_, err = cvt.PointerStringE(data, "/items")
+	assertError(t, err)
+}
+
+func TestPatchE(t *testing.T) {
+	doc := `{"foo": "bar", "list": [1, 2, 3], "obj": {"a": {"b": 1}}}`
+
+	tests := []struct {
+		patch  interface{}
+		expect string
+		isErr  bool
+	}{
+		{`[{"op": "add", "path": "/baz", "value": "qux"}]`, `{"foo": "bar", "list": [1, 2, 3], "obj": {"a": {"b": 1}}, "baz": "qux"}`, false},
+		{`[{"op": "add", "path": "/list/1", "value": 9}]`, `{"foo": "bar", "list": [1, 9, 2, 3], "obj": {"a": {"b": 1}}}`, false},
+		{`[{"op": "add", "path": "/list/3", "value": 9}]`, `{"foo": "bar", "list": [1, 2, 3, 9], "obj": {"a": {"b": 1}}}`, false},
+		{`[{"op": "add", "path": "/list/-", "value": [9]}]`, `{"foo": "bar", "list": [1, 2, 3, [9]], "obj": {"a": {"b": 1}}}`, false},
+		{`[{"op": "add", "path": "/obj/a", "value": {"c": 2}}]`, `{"foo": "bar", "list": [1, 2, 3], "obj": {"a": {"c": 2}}}`, false},
+		{`[{"op": "add", "path": "", "value": {"x": 1}}]`, `{"x": 1}`, false},
+		{`[{"op": "remove", "path": "/foo"}, {"op": "remove", "path": "/list/0"}]`, `{"list": [2, 3], "obj": {"a": {"b": 1}}}`, false},
+		{`[{"op": "replace", "path": "/obj/a/b", "value": "x"}]`, `{"foo": "bar", "list": [1, 2, 3], "obj": {"a": {"b": "x"}}}`, false},
+		{`[{"op": "replace", "path": "/list/2", "value": null}]`, `{"foo": "bar", "list": [1, 2, null], "obj": {"a": {"b": 1}}}`, false},
+		{`[{"op": "move", "from": "/obj/a", "path": "/a"}]`, `{"foo": "bar", "list": [1, 2, 3], "obj": {}, "a": {"b": 1}}`, false},
+		{`[{"op": "move", "from": "/list/0", "path": "/list/-"}]`, `{"foo": "bar", "list": [2, 3, 1], "obj": {"a": {"b": 1}}}`, false},
+		{`[{"op": "copy", "from": "/obj", "path": "/obj2"}, {"op": "add", "path": "/obj2/a/b", "value": 2}]`, `{"foo": "bar", "list": [1, 2, 3], "obj": {"a": {"b": 1}}, "obj2": {"a": {"b": 2}}}`, false},
+		{`[{"op": "test", "path": "/list", "value": [1, 2.0, 3]}, {"op": "test", "path": "/obj", "value": {"a": {"b": 1}}}]`, doc, false},
+		{[]map[string]interface{}{{"op": "test", "path": "/foo", "value": "bar"}, {"op": "add", "path": "/n", "value": 1}}, `{"foo": "bar", "list": [1, 2, 3], "obj": {"a": {"b": 1}}, "n": 1}`, false},
+		{[]interface{}{map[string]interface{}{"op": "add", "path": "/s", "value": TestDecodeItem{"a", 1}}}, `{"foo": "bar", "list": [1, 2, 3], "obj": {"a": {"b": 1}}, "s": {"name": "a", "price": 1}}`, false},
+
+		// errors
+		{`[{"op": "add", "path": "/x/y", "value": 1}]`, "", true},
+		{`[{"op": "add", "path": "/list/4", "value": 1}]`, "", true},
+		{`[{"op": "add", "path": "/list/a", "value": 1}]`, "", true},
+		{`[{"op": "add", "path": "/foo/a", "value": 1}]`, "", true},
+		{`[{"op": "add", "path": "/x"}]`, "", true},
+		{`[{"op": "add", "path": "", "value": [1]}]`, "", true},
+		{`[{"op": "remove", "path": "/x"}]`, "", true},
+		{`[{"op": "remove", "path": "/list/3"}]`, "", true},
+		{`[{"op": "remove", "path": ""}]`, "", true},
+		{`[{"op": "replace", "path": "/x", "value": 1}]`, "", true},
+		{`[{"op": "replace", "path": "/list/-", "value": 1}]`, "", true},
+		{`[{"op": "move", "from": "/obj", "path": "/obj/a/c"}]`, "", true},
+		{`[{"op": "move", "from": "/x", "path": "/y"}]`, "", true},
+		{`[{"op": "copy", "path": "/y"}]`, "", true},
+		{`[{"op": "test", "path": "/foo", "value": "baz"}]`, "", true},
+		{`[{"op": "test", "path": "/list", "value": [1, 2]}]`, "", true},
+		{`[{"op": "test", "path": "/x", "value": 1}]`, "", true},
+		{`[{"op": "unknown", "path": "/foo"}]`, "", true},
+		{`[{"path": "/foo"}]`, "", true},
+		{`[{"op": "add", "value": 1}]`, "", true},
+		{`[{"op": "add", "path": "foo", "value": 1}]`, "", true},
+		{`[1]`, "", true},
+		{`{}`, "", true},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, patch[%+v], expect[%+v], isErr[%v]", i, tt.patch, tt.expect, tt.isErr)
+
+		v, err := cvt.PatchE(doc, tt.patch)
+		if tt.isErr {
+			assertError(t, err, "[HasErr] "+msg)
+			continue
+		}
+
+		assertNoError(t, err, msg)
+		assertEqual(t, jsonText(t, tt.expect), jsonText(t, v), "[WithE] "+msg)
+
+		// non-E
+		v = cvt.Patch(doc, tt.patch)
+		assertEqual(t, jsonText(t, tt.expect), jsonText(t, v), "[NonE] "+msg)
+	}
+
+	assertEqual(t, map[string]interface{}{}, cvt.Patch(doc, `[1]`, map[string]interface{}{}))
+	assertEqual(t, (map[string]interface{})(nil), cvt.Patch(nil, `[]`))
+}
+
+func TestPatchE_Error(t *testing.T) {
+	doc := map[string]interface{}{"list": []interface{}{1, 2}, "obj": map[string]interface{}{"a": 1}}
+
+	// the failed operation is reported, and the doc is not modified
+	_, err := cvt.PatchE(doc, `[{"op": "remove", "path": "/list/0"}, {"op": "remove", "path": "/obj/a"}, {"op": "add", "path": "/list/5", "value": 1}]`)
+	var e *cvt.ElementError
+	assertEqual(t, true, errors.As(err, &e))
+	assertEqual(t, 2, e.Index)
+	var pe *cvt.PathError
+	assertEqual(t, true, errors.As(err, &pe))
+	assertEqual(t, "/5", pe.Segment)
+	assertEqual(t, `index 2: path "/list/5" at "/5": index out of range(5), length 1`, err.Error())
+	assertEqual(t, map[string]interface{}{"list": []interface{}{1, 2}, "obj": map[string]interface{}{"a": 1}}, doc)
+}
+
+// marshal the JSON text or value to the normalized JSON text
+func jsonText(t *testing.T, v interface{}) string {
+	if s, ok := v.(string); ok {
+		if err := json.Unmarshal([]byte(s), &v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// normalize the numbers
+	var vv interface{}
+	_ = json.Unmarshal(b, &vv)
+	b, _ = json.Marshal(vv)
+	return string(b)
+}
@@ -1,8 +1,12 @@
 package cvt
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
+	"time"
 )
 
 // Slice convert an interface to a []interface{} type, with default value
@@ -19,12 +23,31 @@ func Slice(v interface{}, def ...[]interface{}) []interface{} {
 }
 
 // SliceE convert an interface to a []interface{} type
+// * JSON array text of string, []byte or json.RawMessage is decoded, the number is decoded as json.Number,
+// the text is JSON array if it starts with "[" and ends with "]" after trimming spaces, and must be valid
+// * the []byte to the slice of number, such as SliceIntE, keeps the bytes, not decoded as JSON
+// * other string is split into runes
 func SliceE(val interface{}) (sl []interface{}, err error) {
+	sl, _, err = sliceE(val, reflect.Interface)
+	return
+}
+
+// convert an interface to a []interface{} type, and returns the sorted keys if val is a map
+// elem is the kind of target element, the []byte is decoded as JSON only if elem is not a number
+func sliceE(val interface{}, elem reflect.Kind) (sl []interface{}, keys []reflect.Value, err error) {
 	if val == nil {
-		return sl, errUnsupportedTypeNil
+		return sl, nil, errUnsupportedTypeNil
 	}
 
 	_, rv := Indirect(val)
+	if text, ok := jsonArrayText(rv, elem); ok {
+		d := json.NewDecoder(bytes.NewReader(text))
+		d.UseNumber()
+		if err = d.Decode(&sl); err != nil {
+			return nil, nil, fmt.Errorf(formatExtend, newErr(val, "slice"), err)
+		}
+		return
+	}
 
 	switch rv.Kind() {
 	case reflect.String:
@@ -47,7 +70,8 @@ func SliceE(val interface{}) (sl []interface{}, err error) {
 		var length = rv.Len()
 		if length > 0 {
 			sl = make([]interface{}, length)
-			for j, key := range sortedMapKeys(rv) {
+			keys = sortedMapKeys(rv)
+			for j, key := range keys {
 				sl[j] = rv.MapIndex(key).Interface()
 			}
 		}
@@ -59,6 +83,151 @@ func SliceE(val interface{}) (sl []interface{}, err error) {
 	return
 }
 
+// returns the JSON array text of string, json.RawMessage, or []byte if elem is not a number
+//
+//	[]byte{91, 93} to []int is [91 93], not the empty JSON array
+func jsonArrayText(rv reflect.Value, elem reflect.Kind) ([]byte, bool) {
+	var text []byte
+	switch {
+	case rv.Kind() == reflect.String:
+		text = []byte(rv.String())
+	case rv.Type() == typeRawMessage:
+		text = rv.Bytes()
+	case rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 && !isNumberKind(elem):
+		text = rv.Bytes()
+	default:
+		return nil, false
+	}
+
+	text = bytes.TrimSpace(text)
+	if len(text) < 2 || text[0] != '[' || text[len(text)-1] != ']' {
+		return nil, false
+	}
+	return text, true
+}
+
+var typeRawMessage = reflect.TypeOf(json.RawMessage(nil))
+
+// the kind of number which the byte can be converted to
+func isNumberKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// CollectSliceErrors the global default error mode of the typed slice and map converters, such as SliceIntE and StringMapIntE
+// false by default, stop at the first bad element, returns an *ElementError
+// true, convert every element, returns the ElementErrors of all the bad elements, and the bad elements are zero value
+// it's shared by all the callers, use Converter for the per-call mode
+var CollectSliceErrors = false
+
+// ElementError the error of converting an element of slice, array or map
+type ElementError struct {
+	Index int         // the index of element, for map it is the index of sorted keys
+	Key   interface{} // the key of map, nil for slice and array
+	Err   error
+}
+
+func (e *ElementError) Error() string {
+	if e.Key != nil {
+		return fmt.Sprintf("key %#v: %v", e.Key, e.Err)
+	}
+	return fmt.Sprintf("index %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the error of element
+func (e *ElementError) Unwrap() error {
+	return e.Err
+}
+
+// ElementErrors the errors of all the bad elements, returned in the mode of collecting errors
+type ElementErrors []*ElementError
+
+func (es ElementErrors) Error() string {
+	msg := make([]string, len(es))
+	for j, e := range es {
+		msg[j] = e.Error()
+	}
+	return fmt.Sprintf("%d elements failed: %s", len(es), strings.Join(msg, "; "))
+}
+
+// Converter the per-call options of the slice, map and struct converters, see SliceToE, MapToE and DecodeE
+// the nested slice and map are converted by the same options
+//
+//	var ids []int
+//	err := cvt.Converter{CollectErrors: true}.SliceToE(val, &ids)
+type Converter struct {
+	CollectErrors bool        // convert every element, returns the ElementErrors of all the bad elements, same as CollectSliceErrors
+	FloatFormat   FloatFormat // the format of float to string, the zero value is DefaultFloatFormat
+}
+
+// returns the Converter of the global options
+func defaultConverter() Converter {
+	return Converter{CollectErrors: CollectSliceErrors}
+}
+
+// SliceToE convert an interface to the slice that ptr points to, the elements are converted by the converter of their type
+// * the ptr must be a non-nil pointer of slice, such as *[]int, *[]time.Time
+// * the error reports the index of element, by the mode of CollectSliceErrors
+//
+//	var ids []int64
+//	cvt.SliceToE(`[1, "2"]`, &ids) // []int64{1, 2}
+func SliceToE(val interface{}, ptr interface{}) error {
+	return defaultConverter().SliceToE(val, ptr)
+}
+
+// SliceToE convert an interface to the slice that ptr points to, by the options of c
+func (c Converter) SliceToE(val interface{}, ptr interface{}) error {
+	pv := reflect.ValueOf(ptr)
+	if ptr == nil || pv.Kind() != reflect.Ptr || pv.IsNil() || pv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("%w, the ptr must be a non-nil pointer of slice, got %T", errConvFail, ptr)
+	}
+	sl, err := c.convSliceValue(val, pv.Elem().Type())
+	if sl.IsValid() {
+		pv.Elem().Set(sl)
+	}
+	return err
+}
+
+// convert every element of val by conv, make the result by alloc before converting
+// elem is the kind of target element, see sliceE
+// the error reports the index or key of element, by the mode of CollectSliceErrors
+func convSlice(val interface{}, elem reflect.Kind, alloc func(n int), conv func(j int, v interface{}) error) error {
+	return defaultConverter().convSlice(val, elem, alloc, conv)
+}
+
+// convert every element of val by conv, by the mode of c.CollectErrors
+func (c Converter) convSlice(val interface{}, elem reflect.Kind, alloc func(n int), conv func(j int, v interface{}) error) error {
+	list, keys, err := sliceE(val, elem)
+	if err != nil || len(list) == 0 {
+		return err
+	}
+
+	alloc(len(list))
+	var errs ElementErrors
+	for j, v := range list {
+		if err := conv(j, v); err != nil {
+			e := &ElementError{Index: j, Err: err}
+			if keys != nil {
+				e.Key = keys[j].Interface()
+			}
+			if !c.CollectErrors {
+				return e
+			}
+			errs = append(errs, e)
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
 // SliceInt convert an interface to a []int type, with default value
 func SliceInt(v interface{}, def ...[]int) []int {
 	if v, err := SliceIntE(v); err == nil {
@@ -74,22 +243,10 @@ func SliceInt(v interface{}, def ...[]int) []int {
 
 // SliceIntE convert an interface to a []int type
 func SliceIntE(val interface{}) (sl []int, err error) {
-	list, err := SliceE(val)
-	if err != nil {
+	err = convSlice(val, reflect.Int, func(n int) { sl = make([]int, n) }, func(j int, v interface{}) (e error) {
+		sl[j], e = IntE(v)
 		return
-	}
-
-	if len(list) > 0 {
-		var vv int
-		sl = make([]int, len(list))
-		for j, v := range list {
-			vv, err = IntE(v)
-			if err != nil {
-				return
-			}
-			sl[j] = vv
-		}
-	}
+	})
 
 	return
 }
@@ -109,22 +266,10 @@ func SliceInt64(v interface{}, def ...[]int64) []int64 {
 
 // SliceInt64E convert an interface to a []int64 type
 func SliceInt64E(val interface{}) (sl []int64, err error) {
-	list, err := SliceE(val)
-	if err != nil {
+	err = convSlice(val, reflect.Int64, func(n int) { sl = make([]int64, n) }, func(j int, v interface{}) (e error) {
+		sl[j], e = Int64E(v)
 		return
-	}
-
-	if len(list) > 0 {
-		var vv int64
-		sl = make([]int64, len(list))
-		for j, v := range list {
-			vv, err = Int64E(v)
-			if err != nil {
-				return
-			}
-			sl[j] = vv
-		}
-	}
+	})
 
 	return
 }
@@ -144,23 +289,287 @@ func SliceFloat64(v interface{}, def ...[]float64) []float64 {
 
 // SliceFloat64E convert an interface to a []float64 type
 func SliceFloat64E(val interface{}) (sl []float64, err error) {
-	list, err := SliceE(val)
-	if err != nil {
+	err = convSlice(val, reflect.Float64, func(n int) { sl = make([]float64, n) }, func(j int, v interface{}) (e error) {
+		sl[j], e = Float64E(v)
 		return
+	})
+
+	return
+}
+
+// SliceBool convert an interface to a []bool type, with default value
+func SliceBool(v interface{}, def ...[]bool) []bool {
+	if v, err := SliceBoolE(v); err == nil {
+		return v
 	}
 
-	if len(list) > 0 {
-		var vv float64
-		sl = make([]float64, len(list))
-		for j, v := range list {
-			vv, err = Float64E(v)
-			if err != nil {
-				return
-			}
-			sl[j] = vv
-		}
+	if len(def) > 0 {
+		return def[0]
 	}
 
+	return nil
+}
+
+// SliceBoolE convert an interface to a []bool type
+func SliceBoolE(val interface{}) (sl []bool, err error) {
+	err = convSlice(val, reflect.Bool, func(n int) { sl = make([]bool, n) }, func(j int, v interface{}) (e error) {
+		sl[j], e = BoolE(v)
+		return
+	})
+
+	return
+}
+
+// SliceInt8 convert an interface to a []int8 type, with default value
+func SliceInt8(v interface{}, def ...[]int8) []int8 {
+	if v, err := SliceInt8E(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// SliceInt8E convert an interface to a []int8 type
+func SliceInt8E(val interface{}) (sl []int8, err error) {
+	err = convSlice(val, reflect.Int8, func(n int) { sl = make([]int8, n) }, func(j int, v interface{}) (e error) {
+		sl[j], e = Int8E(v)
+		return
+	})
+
+	return
+}
+
+// SliceInt16 convert an interface to a []int16 type, with default value
+func SliceInt16(v interface{}, def ...[]int16) []int16 {
+	if v, err := SliceInt16E(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// SliceInt16E convert an interface to a []int16 type
+func SliceInt16E(val interface{}) (sl []int16, err error) {
+	err = convSlice(val, reflect.Int16, func(n int) { sl = make([]int16, n) }, func(j int, v interface{}) (e error) {
+		sl[j], e = Int16E(v)
+		return
+	})
+
+	return
+}
+
+// SliceInt32 convert an interface to a []int32 type, with default value
+func SliceInt32(v interface{}, def ...[]int32) []int32 {
+	if v, err := SliceInt32E(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// SliceInt32E convert an interface to a []int32 type
+func SliceInt32E(val interface{}) (sl []int32, err error) {
+	err = convSlice(val, reflect.Int32, func(n int) { sl = make([]int32, n) }, func(j int, v interface{}) (e error) {
+		sl[j], e = Int32E(v)
+		return
+	})
+
+	return
+}
+
+// SliceUint convert an interface to a []uint type, with default value
+func SliceUint(v interface{}, def ...[]uint) []uint {
+	if v, err := SliceUintE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// SliceUintE convert an interface to a []uint type
+func SliceUintE(val interface{}) (sl []uint, err error) {
+	err = convSlice(val, reflect.Uint, func(n int) { sl = make([]uint, n) }, func(j int, v interface{}) (e error) {
+		sl[j], e = UintE(v)
+		return
+	})
+
+	return
+}
+
+// SliceUint8 convert an interface to a []uint8 type, with default value
+func SliceUint8(v interface{}, def ...[]uint8) []uint8 {
+	if v, err := SliceUint8E(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// SliceUint8E convert an interface to a []uint8 type
+func SliceUint8E(val interface{}) (sl []uint8, err error) {
+	err = convSlice(val, reflect.Uint8, func(n int) { sl = make([]uint8, n) }, func(j int, v interface{}) (e error) {
+		sl[j], e = Uint8E(v)
+		return
+	})
+
+	return
+}
+
+// SliceUint16 convert an interface to a []uint16 type, with default value
+func SliceUint16(v interface{}, def ...[]uint16) []uint16 {
+	if v, err := SliceUint16E(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// SliceUint16E convert an interface to a []uint16 type
+func SliceUint16E(val interface{}) (sl []uint16, err error) {
+	err = convSlice(val, reflect.Uint16, func(n int) { sl = make([]uint16, n) }, func(j int, v interface{}) (e error) {
+		sl[j], e = Uint16E(v)
+		return
+	})
+
+	return
+}
+
+// SliceUint32 convert an interface to a []uint32 type, with default value
+func SliceUint32(v interface{}, def ...[]uint32) []uint32 {
+	if v, err := SliceUint32E(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// SliceUint32E convert an interface to a []uint32 type
+func SliceUint32E(val interface{}) (sl []uint32, err error) {
+	err = convSlice(val, reflect.Uint32, func(n int) { sl = make([]uint32, n) }, func(j int, v interface{}) (e error) {
+		sl[j], e = Uint32E(v)
+		return
+	})
+
+	return
+}
+
+// SliceUint64 convert an interface to a []uint64 type, with default value
+func SliceUint64(v interface{}, def ...[]uint64) []uint64 {
+	if v, err := SliceUint64E(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// SliceUint64E convert an interface to a []uint64 type
+func SliceUint64E(val interface{}) (sl []uint64, err error) {
+	err = convSlice(val, reflect.Uint64, func(n int) { sl = make([]uint64, n) }, func(j int, v interface{}) (e error) {
+		sl[j], e = Uint64E(v)
+		return
+	})
+
+	return
+}
+
+// SliceFloat32 convert an interface to a []float32 type, with default value
+func SliceFloat32(v interface{}, def ...[]float32) []float32 {
+	if v, err := SliceFloat32E(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// SliceFloat32E convert an interface to a []float32 type
+func SliceFloat32E(val interface{}) (sl []float32, err error) {
+	err = convSlice(val, reflect.Float32, func(n int) { sl = make([]float32, n) }, func(j int, v interface{}) (e error) {
+		sl[j], e = Float32E(v)
+		return
+	})
+
+	return
+}
+
+// SliceTime convert an interface to a []time.Time type, with default value
+func SliceTime(v interface{}, def ...[]time.Time) []time.Time {
+	if v, err := SliceTimeE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// SliceTimeE convert an interface to a []time.Time type
+func SliceTimeE(val interface{}) (sl []time.Time, err error) {
+	err = convSlice(val, reflect.Struct, func(n int) { sl = make([]time.Time, n) }, func(j int, v interface{}) (e error) {
+		sl[j], e = TimeE(v)
+		return
+	})
+
+	return
+}
+
+// SliceDuration convert an interface to a []time.Duration type, with default value
+func SliceDuration(v interface{}, def ...[]time.Duration) []time.Duration {
+	if v, err := SliceDurationE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// SliceDurationE convert an interface to a []time.Duration type
+func SliceDurationE(val interface{}) (sl []time.Duration, err error) {
+	err = convSlice(val, reflect.Int64, func(n int) { sl = make([]time.Duration, n) }, func(j int, v interface{}) (e error) {
+		sl[j], e = DurationE(v)
+		return
+	})
+
 	return
 }
 
@@ -179,23 +588,29 @@ func SliceString(v interface{}, def ...[]string) []string {
 
 // SliceStringE convert an interface to a []string type
 func SliceStringE(val interface{}) (sl []string, err error) {
-	list, err := SliceE(val)
-	if err != nil {
-		return
+	return DefaultFloatFormat.SliceStringE(val)
+}
+
+// SliceString convert an interface to a []string type, with default value, the float is formatted by ff
+func (ff FloatFormat) SliceString(v interface{}, def ...[]string) []string {
+	if v, err := ff.SliceStringE(v); err == nil {
+		return v
 	}
 
-	if len(list) > 0 {
-		var vv string
-		sl = make([]string, len(list))
-		for j, v := range list {
-			vv, err = StringE(v)
-			if err != nil {
-				return
-			}
-			sl[j] = vv
-		}
+	if len(def) > 0 {
+		return def[0]
 	}
 
+	return nil
+}
+
+// SliceStringE convert an interface to a []string type, the float is formatted by ff
+func (ff FloatFormat) SliceStringE(val interface{}) (sl []string, err error) {
+	err = convSlice(val, reflect.String, func(n int) { sl = make([]string, n) }, func(j int, v interface{}) (e error) {
+		sl[j], e = ff.StringE(v)
+		return
+	})
+
 	return
 }
 
@@ -206,6 +621,9 @@ func ColumnsE(val interface{}, field interface{}) (sl []interface{}, err error)
 	}
 
 	_, rv := Indirect(val)
+	if !rv.IsValid() {
+		return nil, errUnsupportedTypeNil
+	}
 
 	switch rv.Kind() {
 	case reflect.Slice, reflect.Array:
@@ -240,6 +658,9 @@ func KeysE(val interface{}) (sl []interface{}, err error) {
 	}
 
 	_, rv := Indirect(val)
+	if !rv.IsValid() {
+		return nil, errUnsupportedTypeNil
+	}
 
 	switch rv.Kind() {
 	case reflect.Map:
@@ -1,8 +1,12 @@
 package cvt_test
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/shockerli/cvt"
 )
@@ -86,12 +90,22 @@ func TestSliceE(t *testing.T) {
 		{&TestStructB{}, []interface{}{"", 0}, false},
 		{&TestStructE{}, []interface{}{0, (*TestStructD)(nil)}, false},
 
+		// JSON array
+		{`["a", 1, 1.5, true, null]`, []interface{}{"a", json.Number("1"), json.Number("1.5"), true, nil}, false},
+		{" [] ", []interface{}{}, false},
+		{[]byte(`[{"a": 1}, [2]]`), []interface{}{map[string]interface{}{"a": json.Number("1")}, []interface{}{json.Number("2")}}, false},
+		{json.RawMessage(`["x"]`), []interface{}{"x"}, false},
+		{AliasTypeString(`["x"]`), []interface{}{"x"}, false},
+		{"[x", []interface{}{'[', 'x'}, false},
+
 		// errors
 		{int(123), nil, true},
 		{uint16(123), nil, true},
 		{float64(12.3), nil, true},
 		{func() {}, nil, true},
 		{nil, nil, true},
+		{"[x]", nil, true},
+		{`["a",]`, nil, true},
 	}
 
 	for i, tt := range tests {
@@ -391,6 +405,400 @@ func TestSliceFloat64E(t *testing.T) {
 	}
 }
 
+func TestSliceBoolE(t *testing.T) {
+	tests := []struct {
+		input  interface{}
+		expect []bool
+		isErr  bool
+	}{
+		{[]int{}, nil, false},
+		{[]int{1, 0, -1}, []bool{true, false, true}, false},
+		{[]string{"true", "false", "yes", "off", "0"}, []bool{true, false, true, false, false}, false},
+		{[]interface{}{1.1, nil, "on"}, []bool{true, false, true}, false},
+		{map[int]bool{2: false, 1: true}, []bool{true, false}, false},
+
+		// errors
+		{int(1), nil, true},
+		{nil, nil, true},
+		{[]string{"hello"}, nil, true},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, input[%+v], expect[%+v], isErr[%v]", i, tt.input, tt.expect, tt.isErr)
+
+		v, err := cvt.SliceBoolE(tt.input)
+		if tt.isErr {
+			assertError(t, err, "[HasErr] "+msg)
+			continue
+		}
+
+		assertNoError(t, err, "[NoErr] "+msg)
+		assertEqual(t, tt.expect, v, "[WithE] "+msg)
+
+		// Non-E test
+		v = cvt.SliceBool(tt.input)
+		assertEqual(t, tt.expect, v, "[NonE] "+msg)
+	}
+}
+
+func TestSliceIntegerE(t *testing.T) {
+	conv := map[string]func(interface{}) (interface{}, error){
+		"int8":   func(v interface{}) (interface{}, error) { return cvt.SliceInt8E(v) },
+		"int16":  func(v interface{}) (interface{}, error) { return cvt.SliceInt16E(v) },
+		"int32":  func(v interface{}) (interface{}, error) { return cvt.SliceInt32E(v) },
+		"uint":   func(v interface{}) (interface{}, error) { return cvt.SliceUintE(v) },
+		"uint8":  func(v interface{}) (interface{}, error) { return cvt.SliceUint8E(v) },
+		"uint16": func(v interface{}) (interface{}, error) { return cvt.SliceUint16E(v) },
+		"uint32": func(v interface{}) (interface{}, error) { return cvt.SliceUint32E(v) },
+		"uint64": func(v interface{}) (interface{}, error) { return cvt.SliceUint64E(v) },
+	}
+
+	tests := []struct {
+		to     string
+		input  interface{}
+		expect interface{}
+		isErr  bool
+	}{
+		{"int8", []interface{}{1, "-2", 3.7, nil}, []int8{1, -2, 3, 0}, false},
+		{"int16", [...]string{"1000", "-1000"}, []int16{1000, -1000}, false},
+		{"int32", map[string]int64{"b": -2, "a": 1}, []int32{1, -2}, false},
+		{"uint", []float64{1, 2.5}, []uint{1, 2}, false},
+		{"uint8", []string{"255", "0"}, []uint8{255, 0}, false},
+		{"uint16", []int{65535}, []uint16{65535}, false},
+		{"uint32", []interface{}{true, false, "8"}, []uint32{1, 0, 8}, false},
+		{"uint64", []string{"18446744073709551615"}, []uint64{math.MaxUint64}, false},
+		{"uint64", []int{}, []uint64(nil), false},
+
+		// errors
+		{"int8", []int{128}, nil, true},
+		{"int16", []int{32768}, nil, true},
+		{"int32", []int64{math.MaxInt32 + 1}, nil, true},
+		{"uint", []int{-1}, nil, true},
+		{"uint8", []int{256}, nil, true},
+		{"uint16", []string{"hello"}, nil, true},
+		{"uint32", int(1), nil, true},
+		{"uint64", nil, nil, true},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, to[%s], input[%+v], expect[%+v], isErr[%v]", i, tt.to, tt.input, tt.expect, tt.isErr)
+
+		v, err := conv[tt.to](tt.input)
+		if tt.isErr {
+			assertError(t, err, "[HasErr] "+msg)
+			continue
+		}
+
+		assertNoError(t, err, "[NoErr] "+msg)
+		assertEqual(t, tt.expect, v, "[WithE] "+msg)
+	}
+}
+
+func TestSliceFloat32E(t *testing.T) {
+	tests := []struct {
+		input  interface{}
+		expect []float32
+		isErr  bool
+	}{
+		{[]int{}, nil, false},
+		{[]int{1, 2, 3}, []float32{1, 2, 3}, false},
+		{[]float64{1.1, -2.2}, []float32{1.1, -2.2}, false},
+		{[]interface{}{1, "-1.1", float32(8.31), nil}, []float32{1, -1.1, 8.31, 0}, false},
+
+		// errors
+		{float64(12.3), nil, true},
+		{nil, nil, true},
+		{[]float64{math.MaxFloat64}, nil, true},
+		{[]string{"a"}, nil, true},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, input[%+v], expect[%+v], isErr[%v]", i, tt.input, tt.expect, tt.isErr)
+
+		v, err := cvt.SliceFloat32E(tt.input)
+		if tt.isErr {
+			assertError(t, err, "[HasErr] "+msg)
+			continue
+		}
+
+		assertNoError(t, err, "[NoErr] "+msg)
+		assertEqual(t, tt.expect, v, "[WithE] "+msg)
+
+		// Non-E test
+		v = cvt.SliceFloat32(tt.input)
+		assertEqual(t, tt.expect, v, "[NonE] "+msg)
+	}
+}
+
+func TestSliceTimeE(t *testing.T) {
+	tests := []struct {
+		input  interface{}
+		expect []time.Time
+		isErr  bool
+	}{
+		{[]int{}, nil, false},
+		{[]interface{}{time1, &time1, "2009-02-13T23:31:30Z"}, []time.Time{time1, time1, time1}, false},
+
+		// errors
+		{int(1), nil, true},
+		{nil, nil, true},
+		{[]string{"hello"}, nil, true},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, input[%+v], expect[%+v], isErr[%v]", i, tt.input, tt.expect, tt.isErr)
+
+		v, err := cvt.SliceTimeE(tt.input)
+		if tt.isErr {
+			assertError(t, err, "[HasErr] "+msg)
+			continue
+		}
+
+		assertNoError(t, err, "[NoErr] "+msg)
+		assertEqual(t, len(tt.expect), len(v), "[WithE] "+msg)
+		for j := range tt.expect {
+			assertEqual(t, true, tt.expect[j].Equal(v[j]), "[WithE] "+msg)
+		}
+	}
+}
+
+func TestSliceDurationE(t *testing.T) {
+	tests := []struct {
+		input  interface{}
+		expect []time.Duration
+		isErr  bool
+	}{
+		{[]int{}, nil, false},
+		{[]interface{}{1, "1h30m", "PT2S", time.Minute}, []time.Duration{1, 90 * time.Minute, 2 * time.Second, time.Minute}, false},
+
+		// errors
+		{int(1), nil, true},
+		{nil, nil, true},
+		{[]string{"hello"}, nil, true},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, input[%+v], expect[%+v], isErr[%v]", i, tt.input, tt.expect, tt.isErr)
+
+		v, err := cvt.SliceDurationE(tt.input)
+		if tt.isErr {
+			assertError(t, err, "[HasErr] "+msg)
+			continue
+		}
+
+		assertNoError(t, err, "[NoErr] "+msg)
+		assertEqual(t, tt.expect, v, "[WithE] "+msg)
+
+		// Non-E test
+		v = cvt.SliceDuration(tt.input)
+		assertEqual(t, tt.expect, v, "[NonE] "+msg)
+	}
+}
+
+func TestSliceE_JSONArray(t *testing.T) {
+	v1, err := cvt.SliceIntE(`[1, "2", 3.5]`)
+	assertNoError(t, err)
+	assertEqual(t, []int{1, 2, 3}, v1)
+
+	v2, err := cvt.SliceStringE([]byte(`["a", "b", 1.0]`))
+	assertNoError(t, err)
+	assertEqual(t, []string{"a", "b", "1.0"}, v2)
+
+	v3, err := cvt.SliceUint64E(json.RawMessage(`[18446744073709551615]`))
+	assertNoError(t, err)
+	assertEqual(t, []uint64{18446744073709551615}, v3)
+
+	v4, err := cvt.SliceBoolE(`[true, 0, "off"]`)
+	assertNoError(t, err)
+	assertEqual(t, []bool{true, false, false}, v4)
+
+	// []byte to number is the bytes, not JSON
+	v5, err := cvt.SliceIntE([]byte{91, 93})
+	assertNoError(t, err)
+	assertEqual(t, []int{91, 93}, v5)
+	v6, err := cvt.SliceUint8E([]byte(`[1]`))
+	assertNoError(t, err)
+	assertEqual(t, []uint8{'[', '1', ']'}, v6)
+	var v7 []float64
+	assertNoError(t, cvt.SliceToE([]byte{91, 93}, &v7))
+	assertEqual(t, []float64{91, 93}, v7)
+	v8, err := cvt.SliceIntE(json.RawMessage(`[91, 93]`))
+	assertNoError(t, err)
+	assertEqual(t, []int{91, 93}, v8)
+	var v9 []string
+	assertNoError(t, cvt.SliceToE([]byte(`["a"]`), &v9))
+	assertEqual(t, []string{"a"}, v9)
+
+	_, err = cvt.SliceIntE(`["a"]`)
+	assertError(t, err)
+	_, err = cvt.SliceFloat64E(`[1, 2,]`)
+	assertError(t, err)
+}
+
+func TestSliceE_ElementError(t *testing.T) {
+	defer func(collect bool) {
+		cvt.CollectSliceErrors = collect
+	}(cvt.CollectSliceErrors)
+
+	tests := []struct {
+		collect bool
+		input   interface{}
+		expect  []int
+		errs    []string
+	}{
+		{false, []interface{}{1, "a", "b"}, nil, []string{`index 1: unable to convert "a" of type string to int, strconv.ParseFloat: parsing "a": invalid syntax`}},
+		{false, map[string]string{"x": "1", "y": "b"}, nil, []string{`key "y": unable to convert "b" of type string to int, strconv.ParseFloat: parsing "b": invalid syntax`}},
+		{false, `[1, 2, "c"]`, nil, []string{`index 2: unable to convert "c" of type string to int, strconv.ParseFloat: parsing "c": invalid syntax`}},
+		{true, []interface{}{1, "a", 3, "b"}, []int{1, 0, 3, 0}, []string{
+			`index 1: unable to convert "a" of type string to int, strconv.ParseFloat: parsing "a": invalid syntax`,
+			`index 3: unable to convert "b" of type string to int, strconv.ParseFloat: parsing "b": invalid syntax`,
+		}},
+		{true, map[int]string{3: "c", 1: "1", 2: "b"}, []int{1, 0, 0}, []string{
+			`key 2: unable to convert "b" of type string to int, strconv.ParseFloat: parsing "b": invalid syntax`,
+			`key 3: unable to convert "c" of type string to int, strconv.ParseFloat: parsing "c": invalid syntax`,
+		}},
+		{true, []string{"1", "2"}, []int{1, 2}, nil},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, collect[%v], input[%+v], expect[%+v]", i, tt.collect, tt.input, tt.expect)
+
+		cvt.CollectSliceErrors = tt.collect
+		v, err := cvt.SliceIntE(tt.input)
+		if tt.errs == nil {
+			assertNoError(t, err, "[NoErr] "+msg)
+			assertEqual(t, tt.expect, v, "[WithE] "+msg)
+			continue
+		}
+
+		assertError(t, err, "[HasErr] "+msg)
+		if !tt.collect {
+			var e *cvt.ElementError
+			assertEqual(t, true, errors.As(err, &e), "[ElementError] "+msg)
+			assertEqual(t, tt.errs[0], err.Error(), "[ElementError] "+msg)
+			continue
+		}
+
+		var es cvt.ElementErrors
+		assertEqual(t, true, errors.As(err, &es), "[ElementErrors] "+msg)
+		assertEqual(t, tt.expect, v, "[WithE] "+msg)
+		assertEqual(t, len(tt.errs), len(es), "[ElementErrors] "+msg)
+		for j, e := range es {
+			assertEqual(t, tt.errs[j], e.Error(), "[ElementErrors] "+msg)
+		}
+	}
+
+	// the element error can be unwrapped
+	cvt.CollectSliceErrors = false
+	_, err := cvt.SliceFloat64E([]string{"NaN", "x"})
+	var e *cvt.ElementError
+	assertEqual(t, true, errors.As(err, &e))
+	assertEqual(t, 1, e.Index)
+	assertEqual(t, nil, e.Key)
+	assertError(t, errors.Unwrap(err))
+	assertEqual(t, "2 elements failed: index 0: a; key \"k\": b", cvt.ElementErrors{
+		{Index: 0, Err: errors.New("a")},
+		{Index: 1, Key: "k", Err: errors.New("b")},
+	}.Error())
+}
+
+func TestConverter_CollectErrors(t *testing.T) {
+	collect := cvt.Converter{CollectErrors: true}
+
+	// slice
+	var sl []int
+	err := collect.SliceToE([]interface{}{1, "a", 3, "b"}, &sl)
+	var es cvt.ElementErrors
+	assertEqual(t, true, errors.As(err, &es))
+	assertEqual(t, 2, len(es))
+	assertEqual(t, []int{1, 0, 3, 0}, sl)
+
+	// the global mode is not changed
+	assertEqual(t, false, cvt.CollectSliceErrors)
+	var sl2 []int
+	err = cvt.SliceToE([]interface{}{1, "a", 3, "b"}, &sl2)
+	var e *cvt.ElementError
+	assertEqual(t, true, errors.As(err, &e))
+	assertEqual(t, 1, e.Index)
+
+	// nested slice
+	var sl3 [][]int64
+	err = collect.SliceToE(`[[1, "a"], [2, "b"]]`, &sl3)
+	assertEqual(t, true, errors.As(err, &es))
+	assertEqual(t, 2, len(es))
+	assertEqual(t, [][]int64{{1, 0}, {2, 0}}, sl3)
+
+	var sl4 []time.Duration
+	assertNoError(t, cvt.SliceToE([]string{"1s", "1m"}, &sl4))
+	assertEqual(t, []time.Duration{time.Second, time.Minute}, sl4)
+
+	// map
+	var m map[string]int
+	err = collect.MapToE(map[string]string{"a": "1", "b": "x", "c": "y"}, &m)
+	assertEqual(t, true, errors.As(err, &es))
+	assertEqual(t, 2, len(es))
+	assertEqual(t, map[string]int{"a": 1}, m)
+
+	// decode
+	var v struct {
+		IDs []int `json:"ids"`
+	}
+	err = collect.DecodeE(`{"ids": [1, "a", "b"]}`, &v)
+	assertEqual(t, true, errors.As(err, &es))
+	assertEqual(t, 2, len(es))
+	var fe *cvt.FieldError
+	assertEqual(t, true, errors.As(err, &fe))
+	assertEqual(t, "ids", fe.Path)
+
+	// invalid ptr
+	assertError(t, collect.SliceToE([]int{1}, nil))
+	assertError(t, collect.SliceToE([]int{1}, sl))
+	assertError(t, collect.SliceToE([]int{1}, (*[]int)(nil)))
+	assertError(t, collect.SliceToE([]int{1}, &m))
+	assertError(t, collect.MapToE(map[string]int{}, &sl))
+}
+
+func TestKeysE_StructTag(t *testing.T) {
+	defer func(tag string) {
+		cvt.StructTag = tag
+	}(cvt.StructTag)
+
+	v, err := cvt.KeysE(TestStructTag{})
+	assertNoError(t, err)
+	assertEqual(t, []interface{}{"id", "name", "age", "Note", "level", "meta"}, v)
+
+	// the values are in the same order
+	v, err = cvt.SliceE(TestStructTag{ID: 1, Password: "x", TestStructTagEmbed: TestStructTagEmbed{Level: 3}})
+	assertNoError(t, err)
+	assertEqual(t, []interface{}{1, "", 0, "", 3, TestStructD{}}, v)
+
+	v, err = cvt.ColumnsE([]TestStructTag{{ID: 1}, {ID: 2}}, "id")
+	assertNoError(t, err)
+	assertEqual(t, []interface{}{1, 2}, v)
+
+	cvt.StructTag = ""
+	v, err = cvt.KeysE(TestStructTag{})
+	assertNoError(t, err)
+	assertEqual(t, []interface{}{"ID", "Name", "Password", "Age", "Note", "Level", "Meta"}, v)
+}
+
+func TestSliceFamily_HasDefault(t *testing.T) {
+	assertEqual(t, []bool{true}, cvt.SliceBool(1, []bool{true}))
+	assertEqual(t, []int8{1}, cvt.SliceInt8(1, []int8{1}))
+	assertEqual(t, []int16{1}, cvt.SliceInt16(1, []int16{1}))
+	assertEqual(t, []int32{1}, cvt.SliceInt32(1, []int32{1}))
+	assertEqual(t, []uint{1}, cvt.SliceUint(1, []uint{1}))
+	assertEqual(t, []uint8{1}, cvt.SliceUint8(1, []uint8{1}))
+	assertEqual(t, []uint16{1}, cvt.SliceUint16(1, []uint16{1}))
+	assertEqual(t, []uint32{1}, cvt.SliceUint32(1, []uint32{1}))
+	assertEqual(t, []uint64{1}, cvt.SliceUint64(1, []uint64{1}))
+	assertEqual(t, []float32{1}, cvt.SliceFloat32(1, []float32{1}))
+	assertEqual(t, []time.Time{time1}, cvt.SliceTime(1, []time.Time{time1}))
+	assertEqual(t, []time.Duration{1}, cvt.SliceDuration(1, []time.Duration{1}))
+	assertEqual(t, []int8(nil), cvt.SliceInt8([]int{128}))
+	assertEqual(t, []time.Time(nil), cvt.SliceTime(nil))
+}
+
 func TestSliceString_HasDefault(t *testing.T) {
 	tests := []struct {
 		input  interface{}
@@ -0,0 +1,260 @@
+package cvt
+
+import (
+	"errors"
+	"strings"
+)
+
+// Splitter the options of splitting a string to slice
+//
+//	cvt.Splitter{Sep: ";", TrimSpace: true}.SplitE("a; b;c") // []string{"a", "b", "c"}
+type Splitter struct {
+	Sep       string // the separator, default ","
+	TrimSpace bool   // trim the spaces of every item, the spaces inside quotes are kept
+	SkipEmpty bool   // skip the empty item, the quoted empty item `""` is kept
+	Quote     bool   // CSV-style quoting, eg: `a,"b,c","say ""hi"""` => a | b,c | say "hi"
+}
+
+// DefaultSplitter the options used by SplitE and the typed Split*E, with custom separator
+var DefaultSplitter = Splitter{Sep: ",", TrimSpace: true, SkipEmpty: true, Quote: true}
+
+var errUnclosedQuote = errors.New("unclosed quote")
+
+// Split convert an interface to a []string type by splitting, with default value
+func (sp Splitter) Split(v interface{}, def ...[]string) []string {
+	if v, err := sp.SplitE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// SplitE convert an interface to a []string type
+// * string, []byte and the alias types are split by the options
+// * other types are same as SliceStringE
+func (sp Splitter) SplitE(val interface{}) ([]string, error) {
+	v, _ := Indirect(val)
+	switch vv := v.(type) {
+	case string:
+		sl, err := sp.split(vv)
+		if e := catch("[]string", val, err); e != nil {
+			return nil, e
+		}
+		return sl, nil
+	case []byte:
+		sl, err := sp.split(string(vv))
+		if e := catch("[]string", val, err); e != nil {
+			return nil, e
+		}
+		return sl, nil
+	}
+
+	return SliceStringE(val)
+}
+
+func (sp Splitter) split(s string) (sl []string, err error) {
+	sep := sp.Sep
+	if sep == "" {
+		sep = ","
+	}
+
+	for i := 0; i <= len(s); {
+		var item string
+		var quoted bool
+
+		start := i
+		if sp.TrimSpace {
+			start += len(s[i:]) - len(strings.TrimLeft(s[i:], " \t\r\n"))
+		}
+
+		if sp.Quote && start < len(s) && s[start] == '"' {
+			// quoted item, the double quote is escaped by `""`
+			var b strings.Builder
+			j := start + 1
+			for {
+				k := strings.IndexByte(s[j:], '"')
+				if k < 0 {
+					return nil, errUnclosedQuote
+				}
+				b.WriteString(s[j : j+k])
+				j += k + 1
+				if j < len(s) && s[j] == '"' {
+					b.WriteByte('"')
+					j++
+					continue
+				}
+				break
+			}
+			item, quoted = b.String(), true
+
+			// only spaces are allowed between the closing quote and separator
+			rest := s[j:]
+			if k := strings.Index(rest, sep); k >= 0 {
+				rest = rest[:k]
+			}
+			if strings.TrimSpace(rest) != "" {
+				return nil, errConvFail
+			}
+			i = j + len(rest)
+		} else {
+			k := strings.Index(s[i:], sep)
+			if k < 0 {
+				k = len(s) - i
+			}
+			item = s[i : i+k]
+			if sp.TrimSpace {
+				item = strings.TrimSpace(item)
+			}
+			i += k
+		}
+
+		if quoted || !sp.SkipEmpty || item != "" {
+			sl = append(sl, item)
+		}
+
+		// skip the separator, or the end
+		i += len(sep)
+	}
+
+	return
+}
+
+// Split convert an interface to a []string type by splitting with sep, with default value
+func Split(v interface{}, sep string, def ...[]string) []string {
+	if v, err := SplitE(v, sep); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// SplitE convert an interface to a []string type by splitting with sep, other options are same as DefaultSplitter
+// for other element types, convert the result by the slice converters, eg: cvt.SliceInt32E(cvt.Split("1,2", ","))
+//
+//	"1, 2,,3" => []string{"1", "2", "3"}
+//	`a,"b,c"` => []string{"a", "b,c"}
+func SplitE(val interface{}, sep string) ([]string, error) {
+	sp := DefaultSplitter
+	sp.Sep = sep
+	return sp.SplitE(val)
+}
+
+// SplitInt convert an interface to a []int type by splitting with sep, with default value
+func SplitInt(v interface{}, sep string, def ...[]int) []int {
+	if v, err := SplitIntE(v, sep); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// SplitIntE convert an interface to a []int type by splitting with sep
+//
+//	"1,2,3" => []int{1, 2, 3}
+func SplitIntE(val interface{}, sep string) ([]int, error) {
+	sl, err := SplitE(val, sep)
+	if err != nil {
+		return nil, err
+	}
+	return SliceIntE(sl)
+}
+
+// SplitInt64 convert an interface to a []int64 type by splitting with sep, with default value
+func SplitInt64(v interface{}, sep string, def ...[]int64) []int64 {
+	if v, err := SplitInt64E(v, sep); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// SplitInt64E convert an interface to a []int64 type by splitting with sep
+func SplitInt64E(val interface{}, sep string) ([]int64, error) {
+	sl, err := SplitE(val, sep)
+	if err != nil {
+		return nil, err
+	}
+	return SliceInt64E(sl)
+}
+
+// SplitUint64 convert an interface to a []uint64 type by splitting with sep, with default value
+func SplitUint64(v interface{}, sep string, def ...[]uint64) []uint64 {
+	if v, err := SplitUint64E(v, sep); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// SplitUint64E convert an interface to a []uint64 type by splitting with sep
+func SplitUint64E(val interface{}, sep string) ([]uint64, error) {
+	sl, err := SplitE(val, sep)
+	if err != nil {
+		return nil, err
+	}
+	return SliceUint64E(sl)
+}
+
+// SplitFloat64 convert an interface to a []float64 type by splitting with sep, with default value
+func SplitFloat64(v interface{}, sep string, def ...[]float64) []float64 {
+	if v, err := SplitFloat64E(v, sep); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// SplitFloat64E convert an interface to a []float64 type by splitting with sep
+func SplitFloat64E(val interface{}, sep string) ([]float64, error) {
+	sl, err := SplitE(val, sep)
+	if err != nil {
+		return nil, err
+	}
+	return SliceFloat64E(sl)
+}
+
+// SplitBool convert an interface to a []bool type by splitting with sep, with default value
+func SplitBool(v interface{}, sep string, def ...[]bool) []bool {
+	if v, err := SplitBoolE(v, sep); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return nil
+}
+
+// SplitBoolE convert an interface to a []bool type by splitting with sep
+func SplitBoolE(val interface{}, sep string) ([]bool, error) {
+	sl, err := SplitE(val, sep)
+	if err != nil {
+		return nil, err
+	}
+	return SliceBoolE(sl)
+}
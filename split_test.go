@@ -0,0 +1,121 @@
+package cvt_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/shockerli/cvt"
+)
+
+func TestSplitter_SplitE(t *testing.T) {
+	tests := []struct {
+		sp     cvt.Splitter
+		input  interface{}
+		expect []string
+		isErr  bool
+	}{
+		{cvt.Splitter{}, "a,b,c", []string{"a", "b", "c"}, false},
+		{cvt.Splitter{}, "a, b,,c,", []string{"a", " b", "", "c", ""}, false},
+		{cvt.Splitter{}, "", []string{""}, false},
+		{cvt.Splitter{TrimSpace: true}, " a , b ", []string{"a", "b"}, false},
+		{cvt.Splitter{SkipEmpty: true}, ",a,,b,", []string{"a", "b"}, false},
+		{cvt.Splitter{SkipEmpty: true}, "", nil, false},
+		{cvt.Splitter{TrimSpace: true, SkipEmpty: true}, "a, ,b", []string{"a", "b"}, false},
+		{cvt.Splitter{Sep: "||"}, "a||b|c", []string{"a", "b|c"}, false},
+		{cvt.Splitter{Sep: ";", TrimSpace: true}, []byte("a; b;c"), []string{"a", "b", "c"}, false},
+		{cvt.Splitter{Quote: true}, `a,"b,c",d`, []string{"a", "b,c", "d"}, false},
+		{cvt.Splitter{Quote: true}, `"say ""hi""",x`, []string{`say "hi"`, "x"}, false},
+		{cvt.Splitter{Quote: true, SkipEmpty: true}, `"",a,`, []string{"", "a"}, false},
+		{cvt.Splitter{Quote: true, TrimSpace: true}, ` " a " , b`, []string{" a ", "b"}, false},
+		{cvt.Splitter{Quote: false}, `a,"b,c"`, []string{"a", `"b`, `c"`}, false},
+		{cvt.Splitter{Sep: ","}, aliasTypeString8d15, []string{"8.15"}, false},
+
+		// not string, same as SliceStringE
+		{cvt.Splitter{}, []int{1, 2}, []string{"1", "2"}, false},
+
+		// errors
+		{cvt.Splitter{Quote: true}, `a,"b`, nil, true},
+		{cvt.Splitter{Quote: true}, `"a"b,c`, nil, true},
+		{cvt.Splitter{}, nil, nil, true},
+		{cvt.Splitter{}, 123, nil, true},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, sp[%+v], input[%+v], expect[%+v], isErr[%v]", i, tt.sp, tt.input, tt.expect, tt.isErr)
+
+		v, err := tt.sp.SplitE(tt.input)
+		if tt.isErr {
+			assertError(t, err, "[HasErr] "+msg)
+			continue
+		}
+
+		assertNoError(t, err, "[NoErr] "+msg)
+		assertEqual(t, tt.expect, v, "[WithE] "+msg)
+
+		// Non-E test
+		v = tt.sp.Split(tt.input)
+		assertEqual(t, tt.expect, v, "[NonE] "+msg)
+	}
+}
+
+func TestSplitE(t *testing.T) {
+	conv := map[string]func(interface{}, string) (interface{}, error){
+		"string":  func(v interface{}, sep string) (interface{}, error) { return cvt.SplitE(v, sep) },
+		"int":     func(v interface{}, sep string) (interface{}, error) { return cvt.SplitIntE(v, sep) },
+		"int64":   func(v interface{}, sep string) (interface{}, error) { return cvt.SplitInt64E(v, sep) },
+		"uint64":  func(v interface{}, sep string) (interface{}, error) { return cvt.SplitUint64E(v, sep) },
+		"float64": func(v interface{}, sep string) (interface{}, error) { return cvt.SplitFloat64E(v, sep) },
+		"bool":    func(v interface{}, sep string) (interface{}, error) { return cvt.SplitBoolE(v, sep) },
+	}
+
+	tests := []struct {
+		to     string
+		input  interface{}
+		sep    string
+		expect interface{}
+		isErr  bool
+	}{
+		{"string", `a, "b,c" ,,d`, ",", []string{"a", "b,c", "d"}, false},
+		{"int", "1,2,3", ",", []int{1, 2, 3}, false},
+		{"int", " 1 | 2 |", "|", []int{1, 2}, false},
+		{"int", "", ",", []int(nil), false},
+		{"int64", []byte("-1,2"), ",", []int64{-1, 2}, false},
+		{"uint64", "18446744073709551615", ",", []uint64{18446744073709551615}, false},
+		{"float64", "1.5; -2", ";", []float64{1.5, -2}, false},
+		{"bool", "true,0,yes,off", ",", []bool{true, false, true, false}, false},
+		{"int", []string{"1", "2"}, ",", []int{1, 2}, false},
+
+		// errors
+		{"int", "1,a,3", ",", nil, true},
+		{"int64", `1,"2`, ",", nil, true},
+		{"uint64", "-1", ",", nil, true},
+		{"float64", "1.5,x", ",", nil, true},
+		{"bool", "hello", ",", nil, true},
+		{"string", nil, ",", nil, true},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, to[%s], input[%+v], sep[%s], expect[%+v], isErr[%v]", i, tt.to, tt.input, tt.sep, tt.expect, tt.isErr)
+
+		v, err := conv[tt.to](tt.input, tt.sep)
+		if tt.isErr {
+			assertError(t, err, "[HasErr] "+msg)
+			continue
+		}
+
+		assertNoError(t, err, "[NoErr] "+msg)
+		assertEqual(t, tt.expect, v, "[WithE] "+msg)
+	}
+}
+
+func TestSplit_HasDefault(t *testing.T) {
+	assertEqual(t, []string{"a", "b"}, cvt.Split("a,b", ","))
+	assertEqual(t, []string{"x"}, cvt.Split(`"a`, ",", []string{"x"}))
+	assertEqual(t, []int{1, 2}, cvt.SplitInt("1,2", ","))
+	assertEqual(t, []int{0}, cvt.SplitInt("a", ",", []int{0}))
+	assertEqual(t, []int64{0}, cvt.SplitInt64("a", ",", []int64{0}))
+	assertEqual(t, []uint64{0}, cvt.SplitUint64("a", ",", []uint64{0}))
+	assertEqual(t, []float64{0}, cvt.SplitFloat64("a", ",", []float64{0}))
+	assertEqual(t, []bool{true}, cvt.SplitBool("a", ",", []bool{true}))
+	assertEqual(t, []bool(nil), cvt.SplitBool("a", ","))
+}
@@ -0,0 +1,167 @@
+package cvt
+
+import (
+	"database/sql"
+)
+
+// NullBool convert an interface to a sql.NullBool type, with default value
+func NullBool(v interface{}, def ...sql.NullBool) sql.NullBool {
+	if v, err := NullBoolE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return sql.NullBool{}
+}
+
+// NullBoolE convert an interface to a sql.NullBool type, returns Valid=false for null value, same as the nullable converters
+func NullBoolE(val interface{}) (sql.NullBool, error) {
+	if isNull(val) {
+		return sql.NullBool{}, nil
+	}
+
+	v, err := BoolE(val)
+	if err != nil {
+		return sql.NullBool{}, err
+	}
+
+	return sql.NullBool{Bool: v, Valid: true}, nil
+}
+
+// NullInt32 convert an interface to a sql.NullInt32 type, with default value
+func NullInt32(v interface{}, def ...sql.NullInt32) sql.NullInt32 {
+	if v, err := NullInt32E(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return sql.NullInt32{}
+}
+
+// NullInt32E convert an interface to a sql.NullInt32 type, returns Valid=false for null value, same as the nullable converters
+func NullInt32E(val interface{}) (sql.NullInt32, error) {
+	if isNull(val) {
+		return sql.NullInt32{}, nil
+	}
+
+	v, err := Int32E(val)
+	if err != nil {
+		return sql.NullInt32{}, err
+	}
+
+	return sql.NullInt32{Int32: v, Valid: true}, nil
+}
+
+// NullInt64 convert an interface to a sql.NullInt64 type, with default value
+func NullInt64(v interface{}, def ...sql.NullInt64) sql.NullInt64 {
+	if v, err := NullInt64E(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return sql.NullInt64{}
+}
+
+// NullInt64E convert an interface to a sql.NullInt64 type, returns Valid=false for null value, same as the nullable converters
+func NullInt64E(val interface{}) (sql.NullInt64, error) {
+	if isNull(val) {
+		return sql.NullInt64{}, nil
+	}
+
+	v, err := Int64E(val)
+	if err != nil {
+		return sql.NullInt64{}, err
+	}
+
+	return sql.NullInt64{Int64: v, Valid: true}, nil
+}
+
+// NullFloat64 convert an interface to a sql.NullFloat64 type, with default value
+func NullFloat64(v interface{}, def ...sql.NullFloat64) sql.NullFloat64 {
+	if v, err := NullFloat64E(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return sql.NullFloat64{}
+}
+
+// NullFloat64E convert an interface to a sql.NullFloat64 type, returns Valid=false for null value, same as the nullable converters
+func NullFloat64E(val interface{}) (sql.NullFloat64, error) {
+	if isNull(val) {
+		return sql.NullFloat64{}, nil
+	}
+
+	v, err := Float64E(val)
+	if err != nil {
+		return sql.NullFloat64{}, err
+	}
+
+	return sql.NullFloat64{Float64: v, Valid: true}, nil
+}
+
+// NullString convert an interface to a sql.NullString type, with default value
+func NullString(v interface{}, def ...sql.NullString) sql.NullString {
+	if v, err := NullStringE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return sql.NullString{}
+}
+
+// NullStringE convert an interface to a sql.NullString type, returns Valid=false for null value, same as the nullable converters
+func NullStringE(val interface{}) (sql.NullString, error) {
+	if isNull(val) {
+		return sql.NullString{}, nil
+	}
+
+	v, err := StringE(val)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+
+	return sql.NullString{String: v, Valid: true}, nil
+}
+
+// NullTime convert an interface to a sql.NullTime type, with default value
+func NullTime(v interface{}, def ...sql.NullTime) sql.NullTime {
+	if v, err := NullTimeE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return sql.NullTime{}
+}
+
+// NullTimeE convert an interface to a sql.NullTime type, returns Valid=false for null value, same as the nullable converters
+func NullTimeE(val interface{}) (sql.NullTime, error) {
+	if isNull(val) {
+		return sql.NullTime{}, nil
+	}
+
+	v, err := TimeE(val)
+	if err != nil {
+		return sql.NullTime{}, err
+	}
+
+	return sql.NullTime{Time: v, Valid: true}, nil
+}
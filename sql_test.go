@@ -0,0 +1,225 @@
+package cvt_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/shockerli/cvt"
+)
+
+// custom driver.Valuer
+type TestValuer struct {
+	v interface{}
+}
+
+func (t TestValuer) Value() (driver.Value, error) {
+	return t.v, nil
+}
+
+type TestValuerPtr struct {
+	v interface{}
+}
+
+func (t *TestValuerPtr) Value() (driver.Value, error) {
+	return t.v, nil
+}
+
+func TestSQLNull_Input(t *testing.T) {
+	var nullTime = sql.NullTime{Time: time1, Valid: true}
+	var nilValuerPtr *TestValuerPtr
+
+	tests := []struct {
+		input   interface{}
+		int     int
+		str     string
+		boolean bool
+		float   float64
+		isNull  bool
+	}{
+		{sql.NullInt64{Int64: 12, Valid: true}, 12, "12", true, 12, false},
+		{&sql.NullInt64{Int64: 12, Valid: true}, 12, "12", true, 12, false},
+		{sql.NullInt32{Int32: 12, Valid: true}, 12, "12", true, 12, false},
+		{sql.NullFloat64{Float64: 12.5, Valid: true}, 12, "12.5", true, 12.5, false},
+		{sql.NullString{String: "12", Valid: true}, 12, "12", true, 12, false},
+		{sql.NullBool{Bool: true, Valid: true}, 1, "true", true, 1, false},
+		{TestValuer{"12"}, 12, "12", true, 12, false},
+		{TestValuer{[]byte("12")}, 12, "12", true, 12, false},
+		{&TestValuerPtr{int64(12)}, 12, "12", true, 12, false},
+
+		// invalid is null
+		{sql.NullInt64{Int64: 12}, 0, "", false, 0, true},
+		{sql.NullString{String: "12"}, 0, "", false, 0, true},
+		{sql.NullTime{Time: time1}, 0, "", false, 0, true},
+		{TestValuer{}, 0, "", false, 0, true},
+		{nilValuerPtr, 0, "", false, 0, true},
+		{&nullTime, 1234567890, "2009-02-13T23:31:30Z", false, 1234567890, false},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, input[%+v]", i, tt.input)
+
+		v, err := cvt.IntE(tt.input)
+		assertNoError(t, err, "[Int] "+msg)
+		assertEqual(t, tt.int, v, "[Int] "+msg)
+
+		s, err := cvt.StringE(tt.input)
+		assertNoError(t, err, "[String] "+msg)
+		assertEqual(t, tt.str, s, "[String] "+msg)
+
+		f, err := cvt.Float64E(tt.input)
+		assertNoError(t, err, "[Float64] "+msg)
+		assertEqual(t, tt.float, f, "[Float64] "+msg)
+
+		if _, ok := tt.input.(*sql.NullTime); !ok {
+			b, err := cvt.BoolE(tt.input)
+			assertNoError(t, err, "[Bool] "+msg)
+			assertEqual(t, tt.boolean, b, "[Bool] "+msg)
+		}
+
+		p, err := cvt.IntNE(tt.input)
+		if err == nil {
+			assertEqual(t, tt.isNull, p == nil, "[IntNE] "+msg)
+		}
+	}
+
+	tm, err := cvt.TimeE(nullTime)
+	assertNoError(t, err)
+	assertEqualTime(t, time1, tm)
+	tm, err = cvt.TimeE(sql.NullTime{})
+	assertNoError(t, err)
+	assertEqualTime(t, time.Time{}, tm)
+	tm, err = cvt.TimeE(sql.NullInt64{Int64: 1234567890, Valid: true})
+	assertNoError(t, err)
+	assertEqualTime(t, time1, tm)
+
+	// the non-scalar APIs keep the struct as is
+	keys, err := cvt.KeysE(sql.NullString{})
+	assertNoError(t, err)
+	assertEqual(t, []interface{}{"String", "Valid"}, keys)
+	assertEqual(t, false, cvt.IsEmpty(sql.NullInt64{Int64: 12}))
+}
+
+// the driver.Valuer with base type of struct, the value is JSON
+type TestValuerAddr struct {
+	City string
+}
+
+func (a TestValuerAddr) Value() (driver.Value, error) {
+	return []byte(`{"City":"` + a.City + `"}`), nil
+}
+
+// the driver.Valuer with base type of int, the value is string
+type TestValuerStatus int
+
+func (s TestValuerStatus) Value() (driver.Value, error) {
+	return fmt.Sprintf("status-%d", int(s)), nil
+}
+
+func TestSQLNull_Valuer(t *testing.T) {
+	addr := TestValuerAddr{City: "Paris"}
+
+	// the struct is kept by the non-scalar APIs
+	v, err := cvt.FieldE(addr, "City")
+	assertNoError(t, err)
+	assertEqual(t, "Paris", v)
+	keys, err := cvt.KeysE(addr)
+	assertNoError(t, err)
+	assertEqual(t, []interface{}{"City"}, keys)
+	sl, err := cvt.SliceE(addr)
+	assertNoError(t, err)
+	assertEqual(t, []interface{}{"Paris"}, sl)
+	assertEqual(t, true, cvt.IsEmpty(TestValuerAddr{}))
+	assertEqual(t, false, cvt.IsEmpty(addr))
+
+	// the scalar converters unwrap the struct
+	s, err := cvt.StringE(addr)
+	assertNoError(t, err)
+	assertEqual(t, `{"City":"Paris"}`, s)
+
+	// the base type of int is converted directly
+	i, err := cvt.IntE(TestValuerStatus(1))
+	assertNoError(t, err)
+	assertEqual(t, 1, i)
+	s, err = cvt.StringE(TestValuerStatus(2))
+	assertNoError(t, err)
+	assertEqual(t, "2", s)
+	f, err := cvt.Float64E(TestValuerStatus(3))
+	assertNoError(t, err)
+	assertEqual(t, float64(3), f)
+	p, err := cvt.IntNE(TestValuerStatus(0))
+	assertNoError(t, err)
+	assertEqual(t, 0, *p)
+}
+
+func TestNullE(t *testing.T) {
+	b, err := cvt.NullBoolE("yes")
+	assertNoError(t, err)
+	assertEqual(t, sql.NullBool{Bool: true, Valid: true}, b)
+	b, err = cvt.NullBoolE(nil)
+	assertNoError(t, err)
+	assertEqual(t, sql.NullBool{}, b)
+	_, err = cvt.NullBoolE("hello")
+	assertError(t, err)
+
+	i32, err := cvt.NullInt32E("12")
+	assertNoError(t, err)
+	assertEqual(t, sql.NullInt32{Int32: 12, Valid: true}, i32)
+	i32, err = cvt.NullInt32E("null")
+	assertNoError(t, err)
+	assertEqual(t, sql.NullInt32{}, i32)
+
+	i64, err := cvt.NullInt64E(sql.NullString{String: "12", Valid: true})
+	assertNoError(t, err)
+	assertEqual(t, sql.NullInt64{Int64: 12, Valid: true}, i64)
+	i64, err = cvt.NullInt64E(sql.NullString{String: "12"})
+	assertNoError(t, err)
+	assertEqual(t, sql.NullInt64{}, i64)
+	_, err = cvt.NullInt64E("hello")
+	assertError(t, err)
+
+	f, err := cvt.NullFloat64E(0)
+	assertNoError(t, err)
+	assertEqual(t, sql.NullFloat64{Float64: 0, Valid: true}, f)
+	_, err = cvt.NullFloat64E("hello")
+	assertError(t, err)
+
+	s, err := cvt.NullStringE(12)
+	assertNoError(t, err)
+	assertEqual(t, sql.NullString{String: "12", Valid: true}, s)
+	s, err = cvt.NullStringE(pointerIntNil)
+	assertNoError(t, err)
+	assertEqual(t, sql.NullString{}, s)
+	_, err = cvt.NullStringE(testing.T{})
+	assertError(t, err)
+
+	tm, err := cvt.NullTimeE(1234567890)
+	assertNoError(t, err)
+	assertEqual(t, true, tm.Valid)
+	assertEqualTime(t, time1, tm.Time)
+	_, err = cvt.NullTimeE("hello")
+	assertError(t, err)
+}
+
+func TestNull(t *testing.T) {
+	assertEqual(t, sql.NullBool{Bool: true, Valid: true}, cvt.NullBool("1"))
+	assertEqual(t, sql.NullBool{Valid: true}, cvt.NullBool("hello", sql.NullBool{Valid: true}))
+	assertEqual(t, sql.NullBool{}, cvt.NullBool("hello"))
+	assertEqual(t, sql.NullInt32{Int32: 1, Valid: true}, cvt.NullInt32("1"))
+	assertEqual(t, sql.NullInt32{}, cvt.NullInt32("hello"))
+	assertEqual(t, sql.NullInt32{Valid: true}, cvt.NullInt32("hello", sql.NullInt32{Valid: true}))
+	assertEqual(t, sql.NullInt64{Int64: 1, Valid: true}, cvt.NullInt64("1"))
+	assertEqual(t, sql.NullInt64{}, cvt.NullInt64("hello"))
+	assertEqual(t, sql.NullInt64{Valid: true}, cvt.NullInt64("hello", sql.NullInt64{Valid: true}))
+	assertEqual(t, sql.NullFloat64{Float64: 1, Valid: true}, cvt.NullFloat64("1"))
+	assertEqual(t, sql.NullFloat64{}, cvt.NullFloat64("hello"))
+	assertEqual(t, sql.NullFloat64{Valid: true}, cvt.NullFloat64("hello", sql.NullFloat64{Valid: true}))
+	assertEqual(t, sql.NullString{String: "1", Valid: true}, cvt.NullString(1))
+	assertEqual(t, sql.NullString{}, cvt.NullString(testing.T{}))
+	assertEqual(t, sql.NullString{Valid: true}, cvt.NullString(testing.T{}, sql.NullString{Valid: true}))
+	assertEqual(t, true, cvt.NullTime(1234567890).Valid)
+	assertEqual(t, sql.NullTime{}, cvt.NullTime("hello"))
+	assertEqual(t, sql.NullTime{Valid: true}, cvt.NullTime("hello", sql.NullTime{Valid: true}))
+}
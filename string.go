@@ -1,9 +1,13 @@
 package cvt
 
 import (
+	"encoding"
 	"encoding/json"
 	"fmt"
+	"math/big"
+	"reflect"
 	"strconv"
+	"time"
 )
 
 // String convert an interface to a string type, with default value
@@ -26,13 +30,59 @@ func StringP(v interface{}, def ...string) *string {
 }
 
 // StringE convert an interface to a string type
+// the float is formatted by DefaultFloatFormat
 func StringE(val interface{}) (string, error) {
+	return DefaultFloatFormat.StringE(val)
+}
+
+// String convert an interface to a string type, with default value, the float is formatted by ff
+func (ff FloatFormat) String(v interface{}, def ...string) string {
+	if v, err := ff.StringE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return ""
+}
+
+// StringE convert an interface to a string type, the float is formatted by ff
+func (ff FloatFormat) StringE(val interface{}) (string, error) {
+	v, e := ff.convString(val)
+	if e := catch("string", val, e); e != nil {
+		return "", e
+	}
+
+	return v, nil
+}
+
+// convert any value to string, the float is formatted by ff
+func (ff FloatFormat) convString(val interface{}) (string, error) {
 	// interface implements
 	switch vv := val.(type) {
+	case time.Time:
+		return vv.Format(TimeLayout), nil
+	case *time.Time:
+		if vv == nil {
+			return "", nil
+		}
+		return vv.Format(TimeLayout), nil
+	case *big.Float:
+		// the String() of *big.Float keeps only 10 digits
+		if vv != nil {
+			return vv.Text('f', -1), nil
+		}
 	case fmt.Stringer:
 		return vv.String(), nil
 	case error:
 		return vv.Error(), nil
+	case encoding.TextMarshaler:
+		vvv, e := vv.MarshalText()
+		if e == nil {
+			return string(vvv), nil
+		}
 	case json.Marshaler:
 		vvv, e := vv.MarshalJSON()
 		if e == nil {
@@ -57,13 +107,17 @@ func StringE(val interface{}) (string, error) {
 	case int, int8, int16, int32, int64:
 		return strconv.FormatInt(Int64(vv), 10), nil
 	case float64:
-		return strconv.FormatFloat(vv, 'f', -1, 64), nil
+		return ff.format(vv, 64)
 	case float32:
-		return strconv.FormatFloat(float64(vv), 'f', -1, 32), nil
+		return ff.format(float64(vv), 32)
+	case complex128:
+		return formatComplexE(vv, 128)
+	case complex64:
+		return formatComplexE(complex128(vv), 64)
 	}
 
 	// indirect type
-	v, rv := Indirect(val)
+	v, rv := indirectScalar(val)
 	switch vv := v.(type) {
 	case nil:
 		return "", nil
@@ -80,10 +134,40 @@ func StringE(val interface{}) (string, error) {
 	case int, int8, int16, int32, int64:
 		return strconv.FormatInt(rv.Int(), 10), nil
 	case float64:
-		return strconv.FormatFloat(vv, 'f', -1, 64), nil
+		return ff.format(vv, 64)
 	case float32:
-		return strconv.FormatFloat(float64(vv), 'f', -1, 32), nil
+		return ff.format(float64(vv), 32)
+	case complex128:
+		return formatComplexE(vv, 128)
+	case complex64:
+		return formatComplexE(complex128(vv), 64)
+	case time.Time:
+		return vv.Format(TimeLayout), nil
+	}
+
+	return "", errConvFail
+}
+
+// UnmarshalTextE convert an interface to a string, and fill the target by its UnmarshalText
+//
+//	var ip net.IP
+//	cvt.UnmarshalTextE("127.0.0.1", &ip)
+func UnmarshalTextE(val interface{}, target encoding.TextUnmarshaler) error {
+	if target == nil {
+		return errUnsupportedTypeNil
+	}
+	if rv := reflect.ValueOf(target); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return errUnsupportedTypeNil
+	}
+
+	s, err := StringE(val)
+	if err != nil {
+		return err
+	}
+
+	if err = target.UnmarshalText([]byte(s)); err != nil {
+		return fmt.Errorf(formatExtend, newErr(val, fmt.Sprintf("%T", target)), err)
 	}
 
-	return "", newErr(val, "string")
+	return nil
 }
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"html/template"
+	"math"
 	"math/big"
+	"net"
 	"testing"
 	"time"
 
@@ -163,7 +165,16 @@ func TestStringE(t *testing.T) {
 		{AliasTypeFloat64(12.34), "12.34", false},
 		{errors.New("errors"), "errors", false},
 		{time.Friday, "Friday", false},
+		{time1, "2009-02-13T23:31:30Z", false},
+		{&time1, "2009-02-13T23:31:30Z", false},
+		{time1.In(time.FixedZone("UTC", 8*3600)), "2009-02-14T07:31:30+08:00", false},
+		{(*time.Time)(nil), "", false},
+		{90 * time.Minute, "1h30m0s", false},
 		{big.NewInt(123), "123", false},
+		{net.ParseIP("127.0.0.1"), "127.0.0.1", false},
+		{TestMarshalText{}, "MarshalText", false},
+		{&TestMarshalText{}, "MarshalText", false},
+		{TestMarshalTextJSON{}, "MarshalText", false},
 		{TestMarshalJSON{}, "MarshalJSON", false},
 		{&TestMarshalJSON{}, "MarshalJSON", false},
 		{template.URL("https://host.foo"), "https://host.foo", false},
@@ -183,6 +194,7 @@ func TestStringE(t *testing.T) {
 		{[]string{}, "", true},
 		{[...]string{}, "", true},
 		{map[int]string{}, "", true},
+		{TestMarshalTextErr{}, "", true},
 	}
 
 	for i, tt := range tests {
@@ -202,3 +214,131 @@ func TestStringE(t *testing.T) {
 		assertEqual(t, tt.expect, v, "[NonE] "+msg)
 	}
 }
+
+func TestUnmarshalTextE(t *testing.T) {
+	var ip net.IP
+	assertNoError(t, cvt.UnmarshalTextE("127.0.0.1", &ip))
+	assertEqual(t, "127.0.0.1", ip.String())
+	assertNoError(t, cvt.UnmarshalTextE([]byte("::1"), &ip))
+	assertEqual(t, "::1", ip.String())
+
+	var i big.Int
+	assertNoError(t, cvt.UnmarshalTextE(json.Number("12345678901234567890"), &i))
+	assertEqual(t, "12345678901234567890", i.String())
+	assertNoError(t, cvt.UnmarshalTextE(AliasTypeString("-12"), &i))
+	assertEqual(t, "-12", i.String())
+
+	var tm time.Time
+	assertNoError(t, cvt.UnmarshalTextE(time1, &tm))
+	assertEqualTime(t, time1, tm)
+
+	// errors
+	assertError(t, cvt.UnmarshalTextE("hello", &ip))
+	assertError(t, cvt.UnmarshalTextE("hello", &i))
+	assertError(t, cvt.UnmarshalTextE(testing.T{}, &i))
+	assertError(t, cvt.UnmarshalTextE("127.0.0.1", nil))
+	assertError(t, cvt.UnmarshalTextE("127.0.0.1", (*net.IP)(nil)))
+}
+
+func TestStringE_TimeLayout(t *testing.T) {
+	defer func(layout string) {
+		cvt.TimeLayout = layout
+	}(cvt.TimeLayout)
+
+	tests := []struct {
+		layout string
+		input  interface{}
+		expect string
+	}{
+		{time.RFC3339Nano, time1.Add(5e8), "2009-02-13T23:31:30.5Z"},
+		{"2006-01-02 15:04:05", time1, "2009-02-13 23:31:30"},
+		{time.Kitchen, &time1, "11:31PM"},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, layout[%s], input[%+v], expect[%v]", i, tt.layout, tt.input, tt.expect)
+
+		cvt.TimeLayout = tt.layout
+		v, err := cvt.StringE(tt.input)
+		assertNoError(t, err, "[NoErr] "+msg)
+		assertEqual(t, tt.expect, v, "[WithE] "+msg)
+	}
+}
+
+func TestFloatFormat(t *testing.T) {
+	tests := []struct {
+		ff     cvt.FloatFormat
+		input  interface{}
+		expect string
+	}{
+		{cvt.DefaultFloatFormat, 8.31, "8.31"},
+		{cvt.DefaultFloatFormat, float32(8.31), "8.31"},
+		{cvt.DefaultFloatFormat, 1e21, "1000000000000000000000"},
+		{cvt.FloatFormat{}, 3.14, "3.14"},
+		{cvt.FloatFormat{}, float32(8.31), "8.31"},
+		{cvt.FloatFormat{Prec: 2}, 3.14159, "3.14"},
+		{cvt.FloatFormat{Verb: 'f', Prec: 2, TrimZeros: true}, 1.5, "1.5"},
+		{cvt.FloatFormat{Verb: 'f', Prec: 2, TrimZeros: true}, 2.0, "2"},
+		{cvt.FloatFormat{Verb: 'e', Prec: 3}, 1234.5, "1.234e+03"},
+		{cvt.FloatFormat{Verb: 'e', Prec: 3, TrimZeros: true}, 1000.0, "1e+03"},
+		{cvt.FloatFormat{Verb: 'g', Prec: -1}, 1e21, "1e+21"},
+		{cvt.FloatFormat{Prec: 2, Grouping: ","}, 1234567.891, "1,234,567.89"},
+		{cvt.FloatFormat{Prec: 0, Grouping: ","}, -123456.0, "-123,456"},
+		{cvt.FloatFormat{Prec: -1, Grouping: " "}, 123.0, "123"},
+		{cvt.FloatFormat{Prec: -1}, math.NaN(), "NaN"},
+		{cvt.FloatFormat{Prec: -1}, math.Inf(-1), "-Inf"},
+		{cvt.FloatFormat{Prec: -1, NaN: "null", PosInf: "Infinity", NegInf: "-Infinity"}, math.NaN(), "null"},
+		{cvt.FloatFormat{Prec: -1, NaN: "null", PosInf: "Infinity", NegInf: "-Infinity"}, math.Inf(1), "Infinity"},
+		{cvt.FloatFormat{Prec: -1, NaN: "null", PosInf: "Infinity", NegInf: "-Infinity"}, math.Inf(-1), "-Infinity"},
+
+		// not float
+		{cvt.FloatFormat{Prec: 2}, 1234, "1234"},
+		{cvt.FloatFormat{Prec: 2}, "1.5", "1.5"},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, ff[%+v], input[%+v], expect[%v]", i, tt.ff, tt.input, tt.expect)
+
+		v, err := tt.ff.StringE(tt.input)
+		assertNoError(t, err, "[NoErr] "+msg)
+		assertEqual(t, tt.expect, v, "[WithE] "+msg)
+
+		v = tt.ff.String(tt.input)
+		assertEqual(t, tt.expect, v, "[NonE] "+msg)
+	}
+
+	ff := cvt.FloatFormat{Prec: 2}
+	assertEqual(t, []string{"1.00", "2.50", "x"}, ff.SliceString([]interface{}{1.0, 2.5, "x"}))
+	m, err := ff.StringMapE(map[float64]int{1.5: 1})
+	assertNoError(t, err)
+	assertEqual(t, map[string]interface{}{"1.50": 1}, m)
+	assertEqual(t, "def", ff.String(testing.T{}, "def"))
+
+	// the value of `string` tag option
+	m, err = ff.StringMapE(struct {
+		Price float64 `json:"price,string"`
+	}{1.5})
+	assertNoError(t, err)
+	assertEqual(t, map[string]interface{}{"price": "1.50"}, m)
+
+	ms, err := ff.StringMapStringE(map[float64]float64{1.5: 2})
+	assertNoError(t, err)
+	assertEqual(t, map[string]string{"1.50": "2.00"}, ms)
+
+	// Converter
+	c := cvt.Converter{FloatFormat: ff}
+	var sl []string
+	assertNoError(t, c.SliceToE([]float64{1, 2.5}, &sl))
+	assertEqual(t, []string{"1.00", "2.50"}, sl)
+	ms = nil
+	assertNoError(t, c.MapToE(map[string]float64{"a": 1}, &ms))
+	assertEqual(t, map[string]string{"a": "1.00"}, ms)
+	var dst struct{ Price string }
+	assertNoError(t, c.DecodeE(map[string]interface{}{"price": 3.0}, &dst))
+	assertEqual(t, "3.00", dst.Price)
+
+	// the zero value is DefaultFloatFormat
+	sl = nil
+	assertNoError(t, cvt.Converter{}.SliceToE([]float64{3.14}, &sl))
+	assertEqual(t, []string{"3.14"}, sl)
+}
@@ -2,7 +2,12 @@ package cvt
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -13,6 +18,14 @@ import (
 // add this variable to setting default time.Location
 var TimeLocation = time.UTC
 
+// TimestampUnit the unit of timestamp, while convert time.Time to an integer or float, and an integer to time.Time
+// default is second, you can change to time.Millisecond, time.Microsecond or time.Nanosecond
+// the unit <= 0 is treated as second
+var TimestampUnit = time.Second
+
+// TimeLayout the layout of string, while convert time.Time to a string
+var TimeLayout = time.RFC3339
+
 // Time convert an interface to a time.Time type, with default value
 func Time(v interface{}, def ...time.Time) time.Time {
 	if v, err := TimeE(v); err == nil {
@@ -60,20 +73,20 @@ func TimeInLocationE(val interface{}, loc *time.Location) (t time.Time, err erro
 		return parseDate(vv, loc)
 	case time.Duration:
 		return time.Unix(int64(vv)/1e9, int64(vv)%1e9), nil
-	case int, int32, int64, uint, uint32, uint64:
-		return time.Unix(Int64(vv), 0), nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr:
+		return ints2time(val)
 	case json.Number:
 		// timestamp
 		vvv, err := vv.Int64()
 		if err == nil {
-			return time.Unix(Int64(vvv), 0), nil
+			return ints2time(vvv)
 		}
 		// time string
 		return parseDate(vv.String(), loc)
 	}
 
 	// indirect type
-	v, _ := Indirect(val)
+	v, _ := indirectScalar(val)
 	switch vv := v.(type) {
 	case nil:
 		return
@@ -81,8 +94,8 @@ func TimeInLocationE(val interface{}, loc *time.Location) (t time.Time, err erro
 		return vv, nil
 	case string:
 		return parseDate(vv, loc)
-	case int, int32, int64, uint, uint32, uint64:
-		return time.Unix(Int64(vv), 0), nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr:
+		return ints2time(val)
 	}
 
 	// interface implements
@@ -112,6 +125,7 @@ var TimeFormats = []string{
 	time.StampMicro,
 	time.StampNano,
 	"2006-01-02T15:04:05",           // ISO8601 without timezone
+	"2006-01-02T15:04Z07:00",        // ISO8601 without seconds
 	"Mon Jan 2 15:04:05 2006 -0700", // Git log date
 	"2006-01-02 15:04:05.999999999 -0700 MST", // Time.String()
 	"2006-01-02",
@@ -145,6 +159,81 @@ var TimeFormats = []string{
 	"2006年01月02日 15时04分05秒",
 }
 
+var errTimestampRange = errors.New("timestamp out of range")
+
+// returns the TimestampUnit, the unit <= 0 is treated as second
+func timestampUnit() time.Duration {
+	if TimestampUnit <= 0 {
+		return time.Second
+	}
+	return TimestampUnit
+}
+
+// convert time.Time to timestamp, in unit of TimestampUnit
+// returns an error if the timestamp is out of int64, eg: time.Time{} in nanosecond
+func time2int64(t time.Time) (int64, error) {
+	unit := timestampUnit()
+	if unit >= time.Second {
+		return t.Unix() / int64(unit/time.Second), nil
+	}
+
+	per := int64(time.Second / unit)
+	sec, frac := t.Unix(), int64(t.Nanosecond())/int64(unit)
+	if sec > (math.MaxInt64-frac)/per || sec < math.MinInt64/per {
+		return 0, errTimestampRange
+	}
+	return sec*per + frac, nil
+}
+
+// convert time.Time to timestamp, in unit of TimestampUnit, the timestamp before 1970 is an error
+func time2uint64(t time.Time) (uint64, error) {
+	ts, err := time2int64(t)
+	if err == nil && ts < 0 {
+		err = errConvFail
+	}
+	if err != nil {
+		return 0, err
+	}
+	return uint64(ts), nil
+}
+
+// convert timestamp in unit of TimestampUnit to time.Time
+func int642time(ts int64) (time.Time, error) {
+	unit := timestampUnit()
+	if unit >= time.Second {
+		per := int64(unit / time.Second)
+		if ts > math.MaxInt64/per || ts < math.MinInt64/per {
+			return time.Time{}, errTimestampRange
+		}
+		return time.Unix(ts*per, 0), nil
+	}
+	per := int64(time.Second / unit)
+	return time.Unix(ts/per, ts%per*int64(unit)), nil
+}
+
+// convert the integer timestamp in unit of TimestampUnit to time.Time
+func ints2time(val interface{}) (time.Time, error) {
+	ts, err := convInt64(val)
+	if err == nil {
+		var t time.Time
+		if t, err = int642time(ts); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, catch("time.Time", val, err)
+}
+
+// convert time.Time to timestamp with fraction, in unit of TimestampUnit
+func time2float64(t time.Time) float64 {
+	unit := timestampUnit()
+	// separate the fraction, avoid precision loss of large number
+	frac := float64(t.Nanosecond()) / float64(unit)
+	if unit >= time.Second {
+		return float64(t.Unix())/float64(unit/time.Second) + frac
+	}
+	return float64(t.Unix())*float64(time.Second/unit) + frac
+}
+
 func parseDate(s string, loc *time.Location) (t time.Time, err error) {
 	for _, dateType := range TimeFormats {
 		if t, err = time.ParseInLocation(dateType, s, loc); err == nil {
@@ -154,3 +243,160 @@ func parseDate(s string, loc *time.Location) (t time.Time, err error) {
 
 	return t, fmt.Errorf("unable to parse date: %s", s)
 }
+
+// TimeInterval the time interval, parsed from ISO 8601 time interval
+type TimeInterval struct {
+	Start time.Time
+	End   time.Time
+	// Repeat the number of repetitions, 0 if not given, -1 if unbounded("R/...")
+	Repeat int
+}
+
+// Interval convert an interface to a TimeInterval type, with default value
+func Interval(v interface{}, def ...TimeInterval) TimeInterval {
+	if v, err := IntervalE(v); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return TimeInterval{}
+}
+
+// IntervalE convert an interface to a TimeInterval type
+func IntervalE(val interface{}) (TimeInterval, error) {
+	return IntervalInLocationE(val, TimeLocation)
+}
+
+// IntervalInLocation convert an interface to a TimeInterval type, with time.Location, with default
+func IntervalInLocation(v interface{}, loc *time.Location, def ...TimeInterval) TimeInterval {
+	if v, err := IntervalInLocationE(v, loc); err == nil {
+		return v
+	}
+
+	if len(def) > 0 {
+		return def[0]
+	}
+
+	return TimeInterval{}
+}
+
+// IntervalInLocationE convert an interface to a TimeInterval type, with time.Location, with error
+// * ISO 8601 time interval string: "<start>/<end>", "<start>/<duration>", "<duration>/<end>"
+// * ISO 8601 repeating interval string: "R5/<start>/<duration>", "R/<start>/<end>"
+// * slice or array of two elements: [start, end]
+// the start and end are converted by TimeInLocationE, the duration is ISO 8601 duration, eg: "P1M", "PT12H"
+func IntervalInLocationE(val interface{}, loc *time.Location) (TimeInterval, error) {
+	if loc == nil {
+		loc = TimeLocation
+	}
+
+	ti, err := convInterval(val, loc)
+	if e := catch("TimeInterval", val, err); e != nil {
+		return TimeInterval{}, e
+	}
+
+	return ti, nil
+}
+
+// convert any value to TimeInterval
+func convInterval(val interface{}, loc *time.Location) (ti TimeInterval, err error) {
+
+	// direct type(for improve performance)
+	switch vv := val.(type) {
+	case TimeInterval:
+		return vv, nil
+	case string:
+		return parseInterval(vv, loc)
+	case []byte:
+		return parseInterval(string(vv), loc)
+	}
+
+	// indirect type
+	v, rv := indirectScalar(val)
+	switch vv := v.(type) {
+	case string:
+		return parseInterval(vv, loc)
+	case []byte:
+		return parseInterval(string(vv), loc)
+	}
+
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		if rv.Len() == 2 {
+			if ti.Start, err = TimeInLocationE(rv.Index(0).Interface(), loc); err != nil {
+				return
+			}
+			if ti.End, err = TimeInLocationE(rv.Index(1).Interface(), loc); err != nil {
+				return
+			}
+			return ti, checkInterval(ti)
+		}
+	}
+
+	return ti, errConvFail
+}
+
+func parseInterval(s string, loc *time.Location) (ti TimeInterval, err error) {
+	parts := strings.Split(strings.TrimSpace(s), "/")
+
+	// repeating interval, R[n]/...
+	if len(parts) == 3 && len(parts[0]) > 0 && (parts[0][0] == 'R' || parts[0][0] == 'r') {
+		if parts[0] == "R" || parts[0] == "r" {
+			ti.Repeat = -1
+		} else if ti.Repeat, err = strconv.Atoi(parts[0][1:]); err != nil || ti.Repeat < 0 {
+			return ti, fmt.Errorf("unable to parse interval: %s", s)
+		}
+		parts = parts[1:]
+	}
+	if len(parts) != 2 {
+		return ti, fmt.Errorf("unable to parse interval: %s", s)
+	}
+
+	var p period
+	switch {
+	case isPeriod(parts[0]) && isPeriod(parts[1]):
+		return ti, fmt.Errorf("unable to parse interval: %s", s)
+	case isPeriod(parts[1]): // <start>/<duration>
+		if ti.Start, err = TimeInLocationE(parts[0], loc); err != nil {
+			return
+		}
+		if p, err = parsePeriod(parts[1]); err != nil {
+			return
+		}
+		ti.End = p.addTo(ti.Start, false)
+	case isPeriod(parts[0]): // <duration>/<end>
+		if ti.End, err = TimeInLocationE(parts[1], loc); err != nil {
+			return
+		}
+		if p, err = parsePeriod(parts[0]); err != nil {
+			return
+		}
+		ti.Start = p.addTo(ti.End, true)
+	default: // <start>/<end>
+		if ti.Start, err = TimeInLocationE(parts[0], loc); err != nil {
+			return
+		}
+		if ti.End, err = TimeInLocationE(parts[1], loc); err != nil {
+			return
+		}
+	}
+
+	return ti, checkInterval(ti)
+}
+
+func isPeriod(s string) bool {
+	return len(s) > 0 && (s[0] == 'P' || s[0] == 'p')
+}
+
+var errIntervalEnd = errors.New("end is before start")
+
+// the end of interval must not be before the start
+func checkInterval(ti TimeInterval) error {
+	if ti.End.Before(ti.Start) {
+		return errIntervalEnd
+	}
+	return nil
+}
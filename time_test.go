@@ -3,6 +3,7 @@ package cvt_test
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"testing"
 	"time"
 
@@ -250,3 +251,178 @@ func TestTimeE(t *testing.T) {
 		assertEqualTime(t, tt.expect, v, "[NonE] "+msg)
 	}
 }
+
+func TestTimeE_TimestampUnit(t *testing.T) {
+	defer func(unit time.Duration) {
+		cvt.TimestampUnit = unit
+	}(cvt.TimestampUnit)
+
+	tm := time1.Add(123456789)
+	tests := []struct {
+		unit   time.Duration
+		input  interface{}
+		expect time.Time
+	}{
+		{time.Second, 1234567890, time1},
+		{time.Millisecond, int64(1234567890123), time1.Add(123 * time.Millisecond)},
+		{time.Microsecond, uint64(1234567890123456), time1.Add(123456 * time.Microsecond)},
+		{time.Nanosecond, json.Number("1234567890123456789"), tm},
+		{time.Minute, 20576131, time1.Add(-30 * time.Second)},
+		{time.Millisecond, -1500, time.Unix(-2, 5e8)},
+		{time.Millisecond, int16(1500), time.Unix(1, 5e8)},
+		{time.Second, int8(-1), time.Unix(-1, 0)},
+		{time.Second, uint8(200), time.Unix(200, 0)},
+		{time.Millisecond, uint16(1500), time.Unix(1, 5e8)},
+		{time.Millisecond, AliasTypeInt(1500), time.Unix(1, 5e8)},
+		{0, 1234567890, time1},
+		{-time.Second, 1234567890, time1},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, unit[%s], input[%+v], expect[%v]", i, tt.unit, tt.input, tt.expect)
+
+		cvt.TimestampUnit = tt.unit
+		v, err := cvt.TimeE(tt.input)
+		assertNoError(t, err, "[NoErr] "+msg)
+		assertEqual(t, true, tt.expect.Equal(v), "[WithE] "+msg)
+
+		// round trip
+		ts, err := cvt.Int64E(v)
+		assertNoError(t, err, "[NoErr] "+msg)
+		assertEqual(t, true, tt.expect.Equal(cvt.Time(ts)), "[RoundTrip] "+msg)
+	}
+
+	// out of range
+	cvt.TimestampUnit = time.Hour
+	_, err := cvt.TimeE(int64(math.MaxInt64 / 1000))
+	assertError(t, err)
+	cvt.TimestampUnit = time.Second
+	_, err = cvt.TimeE(uint64(math.MaxUint64))
+	assertError(t, err)
+}
+
+func TestInterval_HasDefault(t *testing.T) {
+	var def = cvt.TimeInterval{Start: time1, End: time1}
+
+	tests := []struct {
+		input  interface{}
+		def    cvt.TimeInterval
+		expect cvt.TimeInterval
+	}{
+		// supported value, def is not used, def != expect
+		{"2024-01-01/2024-02-01", def, cvt.TimeInterval{
+			Start: time.Date(2024, 1, 1, 0, 0, 0, 0, cvt.TimeLocation),
+			End:   time.Date(2024, 2, 1, 0, 0, 0, 0, cvt.TimeLocation),
+		}},
+
+		// unsupported value, def == expect
+		{"2024-01-01", def, def},
+		{"2024-02-01/2024-01-01", def, def},
+		{testing.T{}, def, def},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf("i = %d, input[%+v], def[%+v], expect[%+v]", i, tt.input, tt.def, tt.expect)
+
+		v := cvt.Interval(tt.input, tt.def)
+		assertEqualTime(t, tt.expect.Start, v.Start, "[NonE] "+msg)
+		assertEqualTime(t, tt.expect.End, v.End, "[NonE] "+msg)
+		assertEqual(t, tt.expect.Repeat, v.Repeat, "[NonE] "+msg)
+	}
+}
+
+func TestIntervalE(t *testing.T) {
+	var (
+		jan1 = time.Date(2024, 1, 1, 0, 0, 0, 0, cvt.TimeLocation)
+		feb1 = time.Date(2024, 2, 1, 0, 0, 0, 0, cvt.TimeLocation)
+	)
+
+	tests := []struct {
+		input  interface{}
+		expect cvt.TimeInterval
+		isErr  bool
+	}{
+		// <start>/<end>
+		{"2024-01-01/2024-02-01", cvt.TimeInterval{Start: jan1, End: feb1}, false},
+		{[]byte("2024-01-01/2024-02-01"), cvt.TimeInterval{Start: jan1, End: feb1}, false},
+		{AliasTypeString("2024-01-01/2024-02-01"), cvt.TimeInterval{Start: jan1, End: feb1}, false},
+		{"2024-01-01T00:00:00Z/2024-01-01T08:00:00+08:00", cvt.TimeInterval{Start: jan1, End: jan1}, false},
+		{[]string{"2024-01-01", "2024-02-01"}, cvt.TimeInterval{Start: jan1, End: feb1}, false},
+		{[2]interface{}{jan1, feb1.Unix()}, cvt.TimeInterval{Start: jan1, End: feb1}, false},
+		{cvt.TimeInterval{Start: jan1, End: feb1}, cvt.TimeInterval{Start: jan1, End: feb1}, false},
+
+		// <start>/<duration>
+		{"2024-01-01T00:00Z/P1M", cvt.TimeInterval{Start: jan1, End: feb1}, false},
+		{"2024-01-31/P1M", cvt.TimeInterval{Start: jan1.AddDate(0, 0, 30), End: jan1.AddDate(0, 0, 30).AddDate(0, 1, 0)}, false},
+		{"2024-01-01/PT36H", cvt.TimeInterval{Start: jan1, End: jan1.Add(36 * time.Hour)}, false},
+		{"2024-01-01/P1Y2M3DT4H5M6.5S", cvt.TimeInterval{
+			Start: jan1,
+			End:   time.Date(2025, 3, 4, 4, 5, 6, 5e8, cvt.TimeLocation),
+		}, false},
+
+		// <duration>/<end>
+		{"P1M/2024-02-01", cvt.TimeInterval{Start: jan1, End: feb1}, false},
+		{"P2W/2024-01-15", cvt.TimeInterval{Start: jan1, End: jan1.AddDate(0, 0, 14)}, false},
+
+		// repeating
+		{"R5/2024-01-01/P1D", cvt.TimeInterval{Start: jan1, End: jan1.AddDate(0, 0, 1), Repeat: 5}, false},
+		{"R0/2024-01-01/2024-02-01", cvt.TimeInterval{Start: jan1, End: feb1}, false},
+		{"R/2024-01-01/2024-02-01", cvt.TimeInterval{Start: jan1, End: feb1, Repeat: -1}, false},
+
+		// errors
+		{nil, cvt.TimeInterval{}, true},
+		{"", cvt.TimeInterval{}, true},
+		{"2024-01-01", cvt.TimeInterval{}, true},
+		{"2024-02-01/2024-01-01", cvt.TimeInterval{}, true},
+		{"P1D/P1D", cvt.TimeInterval{}, true},
+		{"2024-01-01/P1X", cvt.TimeInterval{}, true},
+		{"P1X/2024-01-01", cvt.TimeInterval{}, true},
+		{"hello/2024-01-01", cvt.TimeInterval{}, true},
+		{"2024-01-01/world", cvt.TimeInterval{}, true},
+		{"Rx/2024-01-01/P1D", cvt.TimeInterval{}, true},
+		{"R-1/2024-01-01/P1D", cvt.TimeInterval{}, true},
+		{"2024-01-01/2024-01-02/2024-01-03", cvt.TimeInterval{}, true},
+		{[]string{"2024-01-01"}, cvt.TimeInterval{}, true},
+		{[]string{"hello", "2024-01-01"}, cvt.TimeInterval{}, true},
+		{[]string{"2024-01-01", "world"}, cvt.TimeInterval{}, true},
+		{[]string{"2024-02-01", "2024-01-01"}, cvt.TimeInterval{}, true},
+		{testing.T{}, cvt.TimeInterval{}, true},
+	}
+
+	for i, tt := range tests {
+		msg := fmt.Sprintf(
+			"i = %d, input[%+v], expect[%+v], isErr[%v]",
+			i, tt.input, tt.expect, tt.isErr,
+		)
+
+		v, err := cvt.IntervalE(tt.input)
+		if tt.isErr {
+			assertError(t, err, "[HasErr] "+msg)
+			continue
+		}
+
+		assertNoError(t, err, "[NoErr] "+msg)
+		assertEqualTime(t, tt.expect.Start, v.Start, "[WithE] "+msg)
+		assertEqualTime(t, tt.expect.End, v.End, "[WithE] "+msg)
+		assertEqual(t, tt.expect.Repeat, v.Repeat, "[WithE] "+msg)
+	}
+
+	// the error is wrapped with the input and target type
+	_, err := cvt.IntervalE("bad/2024-01-01")
+	assertEqual(t, `unable to convert "bad/2024-01-01" of type string to TimeInterval, unable to parse date: bad`, fmt.Sprint(err))
+	_, err = cvt.IntervalE([]string{"2024-02-01", "2024-01-01"})
+	assertEqual(t, `unable to convert []string{"2024-02-01", "2024-01-01"} of type []string to TimeInterval, end is before start`, fmt.Sprint(err))
+}
+
+func TestIntervalInLocationE(t *testing.T) {
+	v, err := cvt.IntervalInLocationE("2024-01-01 08:00:00/PT1H", locUTC8)
+	assertNoError(t, err)
+	assertEqualTime(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), v.Start)
+	assertEqualTime(t, time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC), v.End)
+
+	v = cvt.IntervalInLocation("hello", locUTC8, cvt.TimeInterval{Repeat: 3})
+	assertEqual(t, 3, v.Repeat)
+
+	v = cvt.IntervalInLocation("2024-01-01/2024-01-02", nil)
+	assertEqualTime(t, time.Date(2024, 1, 1, 0, 0, 0, 0, cvt.TimeLocation), v.Start)
+}